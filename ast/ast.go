@@ -18,6 +18,7 @@ import (
 type Node interface {
 	GetCode() string
 	GetDebugString() string
+	GetPosition() token.Position
 }
 
 type Statement interface {
@@ -28,6 +29,10 @@ type Expression interface {
 	Node
 }
 
+type Pattern interface {
+	Node
+}
+
 ////////////////////////////////////////////////////////////////////////////////
 // STRUCTURES
 ////////////////////////////////////////////////////////////////////////////////
@@ -39,7 +44,17 @@ type Program struct {
 type LetStatement struct {
 	LetToken   token.Token
 	Identifier *Identifier
+	Pattern    Pattern
+	Expression Expression
+	Doc        string
+}
+
+type ConstStatement struct {
+	ConstToken token.Token
+	Identifier *Identifier
+	Pattern    Pattern
 	Expression Expression
+	Doc        string
 }
 
 type ReturnStatement struct {
@@ -47,6 +62,28 @@ type ReturnStatement struct {
 	Expression Expression
 }
 
+type WhileStatement struct {
+	Token     token.Token
+	Condition Expression
+	Body      *BlockStatement
+}
+
+type ForStatement struct {
+	Token    token.Token
+	Key      *Identifier
+	Value    *Identifier
+	Iterable Expression
+	Body     *BlockStatement
+}
+
+type BreakStatement struct {
+	Token token.Token
+}
+
+type ContinueStatement struct {
+	Token token.Token
+}
+
 type ExpressionStatement struct {
 	Token      token.Token
 	Expression Expression
@@ -70,11 +107,44 @@ type InfixExpression struct {
 	RHSExpression Expression
 }
 
+type AssignmentExpression struct {
+	Token    token.Token
+	Target   Expression
+	Operator string
+	Value    Expression
+}
+
 type IfExpression struct {
 	IfToken   token.Token
 	Condition Expression
 	Then      *BlockStatement
 	Else      *BlockStatement
+	ElseIf    *IfExpression
+}
+
+type SwitchExpression struct {
+	Token   token.Token
+	Subject Expression
+	Cases   []*SwitchCase
+}
+
+type SwitchCase struct {
+	Token   token.Token
+	Default bool
+	Values  []Expression
+	Body    *BlockStatement
+}
+
+type MatchExpression struct {
+	Token   token.Token
+	Subject Expression
+	Arms    []*MatchArm
+}
+
+type MatchArm struct {
+	Pattern Pattern
+	Guard   Expression
+	Body    Statement
 }
 
 type CallExpression struct {
@@ -93,22 +163,43 @@ type Integer struct {
 	Value int64
 }
 
+type Float struct {
+	Token token.Token
+	Value float64
+}
+
 type Boolean struct {
 	Token token.Token
 	Value bool
 }
 
+type Null struct {
+	Token token.Token
+}
+
 type Function struct {
 	Token      token.Token
 	Parameters []*Identifier
+	Defaults   []Expression
+	Rest       *Identifier
 	Body       *BlockStatement
 }
 
+type Spread struct {
+	Token      token.Token
+	Expression Expression
+}
+
 type String struct {
 	Token token.Token
 	Value string
 }
 
+type InterpolatedString struct {
+	Token token.Token
+	Parts []Expression
+}
+
 type Array struct {
 	Token    token.Token
 	Elements []Expression
@@ -118,11 +209,37 @@ type Index struct {
 	Token                token.Token
 	IdentifierExpression Expression
 	IndexExpression      Expression
+	Optional             bool
 }
 
 type Hash struct {
 	Token token.Token
-	Pairs map[Expression]Expression
+	Pairs []*HashPair
+}
+
+type HashPair struct {
+	Key   Expression
+	Value Expression
+}
+
+type Wildcard struct {
+	Token token.Token
+}
+
+type ArrayPattern struct {
+	Token    token.Token
+	Elements []Pattern
+	Rest     *Identifier
+}
+
+type HashPattern struct {
+	Token token.Token
+	Pairs []*HashPatternPair
+}
+
+type HashPatternPair struct {
+	Key   Expression
+	Value Pattern
 }
 
 ////////////////////////////////////////////////////////////////////////////////
@@ -136,6 +253,13 @@ func (p *Program) GetCode() string {
 	return ""
 }
 
+func (p *Program) GetPosition() token.Position {
+	if len(p.Statements) > 0 {
+		return p.Statements[0].GetPosition()
+	}
+	return token.Position{}
+}
+
 func (p *Program) GetDebugString() string {
 	var out bytes.Buffer
 	for _, statement := range p.Statements {
@@ -148,10 +272,18 @@ func (ls *LetStatement) GetCode() string {
 	return ls.LetToken.Code
 }
 
+func (ls *LetStatement) GetPosition() token.Position {
+	return ls.LetToken.Position
+}
+
 func (ls *LetStatement) GetDebugString() string {
 	var out bytes.Buffer
 	out.WriteString(ls.GetCode() + " ")
-	out.WriteString(ls.Identifier.GetCode() + "=")
+	if ls.Pattern != nil {
+		out.WriteString(ls.Pattern.GetDebugString() + "=")
+	} else {
+		out.WriteString(ls.Identifier.GetCode() + "=")
+	}
 	if ls.Expression != nil {
 		out.WriteString(ls.Expression.GetDebugString())
 	}
@@ -159,10 +291,37 @@ func (ls *LetStatement) GetDebugString() string {
 	return out.String()
 }
 
+func (cs *ConstStatement) GetCode() string {
+	return cs.ConstToken.Code
+}
+
+func (cs *ConstStatement) GetPosition() token.Position {
+	return cs.ConstToken.Position
+}
+
+func (cs *ConstStatement) GetDebugString() string {
+	var out bytes.Buffer
+	out.WriteString(cs.GetCode() + " ")
+	if cs.Pattern != nil {
+		out.WriteString(cs.Pattern.GetDebugString() + "=")
+	} else {
+		out.WriteString(cs.Identifier.GetCode() + "=")
+	}
+	if cs.Expression != nil {
+		out.WriteString(cs.Expression.GetDebugString())
+	}
+	out.WriteString("; ")
+	return out.String()
+}
+
 func (rs *ReturnStatement) GetCode() string {
 	return rs.Token.Code
 }
 
+func (rs *ReturnStatement) GetPosition() token.Position {
+	return rs.Token.Position
+}
+
 func (rs *ReturnStatement) GetDebugString() string {
 	var out bytes.Buffer
 	out.WriteString(rs.GetCode() + " ")
@@ -173,10 +332,78 @@ func (rs *ReturnStatement) GetDebugString() string {
 	return out.String()
 }
 
+func (ws *WhileStatement) GetCode() string {
+	return ws.Token.Code
+}
+
+func (ws *WhileStatement) GetPosition() token.Position {
+	return ws.Token.Position
+}
+
+func (ws *WhileStatement) GetDebugString() string {
+	var out bytes.Buffer
+	out.WriteString("while ")
+	out.WriteString(ws.Condition.GetDebugString())
+	out.WriteString(" ")
+	out.WriteString(ws.Body.GetDebugString())
+	return out.String()
+}
+
+func (fs *ForStatement) GetCode() string {
+	return fs.Token.Code
+}
+
+func (fs *ForStatement) GetPosition() token.Position {
+	return fs.Token.Position
+}
+
+func (fs *ForStatement) GetDebugString() string {
+	var out bytes.Buffer
+	out.WriteString("for (")
+	if fs.Key != nil {
+		out.WriteString(fs.Key.GetDebugString())
+		out.WriteString(", ")
+	}
+	out.WriteString(fs.Value.GetDebugString())
+	out.WriteString(" in ")
+	out.WriteString(fs.Iterable.GetDebugString())
+	out.WriteString(") ")
+	out.WriteString(fs.Body.GetDebugString())
+	return out.String()
+}
+
+func (bs *BreakStatement) GetCode() string {
+	return bs.Token.Code
+}
+
+func (bs *BreakStatement) GetPosition() token.Position {
+	return bs.Token.Position
+}
+
+func (bs *BreakStatement) GetDebugString() string {
+	return bs.GetCode() + ";"
+}
+
+func (cs *ContinueStatement) GetCode() string {
+	return cs.Token.Code
+}
+
+func (cs *ContinueStatement) GetPosition() token.Position {
+	return cs.Token.Position
+}
+
+func (cs *ContinueStatement) GetDebugString() string {
+	return cs.GetCode() + ";"
+}
+
 func (es *ExpressionStatement) GetCode() string {
 	return es.Token.Code
 }
 
+func (es *ExpressionStatement) GetPosition() token.Position {
+	return es.Token.Position
+}
+
 func (es *ExpressionStatement) GetDebugString() string {
 	if es.Expression != nil {
 		return es.Expression.GetDebugString() + ";"
@@ -188,6 +415,10 @@ func (bs *BlockStatement) GetCode() string {
 	return bs.Token.Code
 }
 
+func (bs *BlockStatement) GetPosition() token.Position {
+	return bs.Token.Position
+}
+
 func (bs *BlockStatement) GetDebugString() string {
 	var out bytes.Buffer
 	out.WriteString("{")
@@ -202,6 +433,10 @@ func (pe *PrefixExpression) GetCode() string {
 	return pe.PrefixToken.Code
 }
 
+func (pe *PrefixExpression) GetPosition() token.Position {
+	return pe.PrefixToken.Position
+}
+
 func (pe *PrefixExpression) GetDebugString() string {
 	var out bytes.Buffer
 	out.WriteString("(")
@@ -215,6 +450,10 @@ func (ie *InfixExpression) GetCode() string {
 	return ie.InfixToken.Code
 }
 
+func (ie *InfixExpression) GetPosition() token.Position {
+	return ie.InfixToken.Position
+}
+
 func (ie *InfixExpression) GetDebugString() string {
 	var out bytes.Buffer
 	out.WriteString("(")
@@ -225,27 +464,111 @@ func (ie *InfixExpression) GetDebugString() string {
 	return out.String()
 }
 
+func (ae *AssignmentExpression) GetCode() string {
+	return ae.Token.Code
+}
+
+func (ae *AssignmentExpression) GetPosition() token.Position {
+	return ae.Target.GetPosition()
+}
+
+func (ae *AssignmentExpression) GetDebugString() string {
+	var out bytes.Buffer
+	out.WriteString("(")
+	out.WriteString(ae.Target.GetDebugString())
+	out.WriteString(ae.Operator)
+	out.WriteString(ae.Value.GetDebugString())
+	out.WriteString(")")
+	return out.String()
+}
+
 func (ie *IfExpression) GetCode() string {
 	return ie.IfToken.Code
 }
 
+func (ie *IfExpression) GetPosition() token.Position {
+	return ie.IfToken.Position
+}
+
 func (ie *IfExpression) GetDebugString() string {
 	var out bytes.Buffer
 	out.WriteString("if ")
 	out.WriteString(ie.Condition.GetDebugString())
 	out.WriteString(" then ")
 	out.WriteString(ie.Then.GetDebugString())
-	if ie.Else != nil {
+	if ie.ElseIf != nil {
+		out.WriteString(" else ")
+		out.WriteString(ie.ElseIf.GetDebugString())
+	} else if ie.Else != nil {
 		out.WriteString(" else ")
 		out.WriteString(ie.Else.GetDebugString())
 	}
 	return out.String()
 }
 
+func (se *SwitchExpression) GetCode() string {
+	return se.Token.Code
+}
+
+func (se *SwitchExpression) GetPosition() token.Position {
+	return se.Token.Position
+}
+
+func (se *SwitchExpression) GetDebugString() string {
+	var out bytes.Buffer
+	out.WriteString("switch ")
+	out.WriteString(se.Subject.GetDebugString())
+	out.WriteString(" {")
+	for _, c := range se.Cases {
+		if c.Default {
+			out.WriteString("default: ")
+		} else {
+			values := []string{}
+			for _, value := range c.Values {
+				values = append(values, value.GetDebugString())
+			}
+			out.WriteString("case " + strings.Join(values, ",") + ": ")
+		}
+		out.WriteString(c.Body.GetDebugString())
+	}
+	out.WriteString("}")
+	return out.String()
+}
+
+func (me *MatchExpression) GetCode() string {
+	return me.Token.Code
+}
+
+func (me *MatchExpression) GetPosition() token.Position {
+	return me.Token.Position
+}
+
+func (me *MatchExpression) GetDebugString() string {
+	var out bytes.Buffer
+	arms := []string{}
+	for _, arm := range me.Arms {
+		code := arm.Pattern.GetDebugString()
+		if arm.Guard != nil {
+			code += " if " + arm.Guard.GetDebugString()
+		}
+		arms = append(arms, code+" => "+arm.Body.GetDebugString())
+	}
+	out.WriteString("match ")
+	out.WriteString(me.Subject.GetDebugString())
+	out.WriteString(" {")
+	out.WriteString(strings.Join(arms, ", "))
+	out.WriteString("}")
+	return out.String()
+}
+
 func (ce *CallExpression) GetCode() string {
 	return ce.Token.Code
 }
 
+func (ce *CallExpression) GetPosition() token.Position {
+	return ce.Token.Position
+}
+
 func (ce *CallExpression) GetDebugString() string {
 	var out bytes.Buffer
 	args := []string{}
@@ -263,6 +586,10 @@ func (i *Identifier) GetCode() string {
 	return i.Token.Code
 }
 
+func (i *Identifier) GetPosition() token.Position {
+	return i.Token.Position
+}
+
 func (i *Identifier) GetDebugString() string {
 	return i.Value
 }
@@ -271,27 +598,70 @@ func (i *Integer) GetCode() string {
 	return i.Token.Code
 }
 
+func (i *Integer) GetPosition() token.Position {
+	return i.Token.Position
+}
+
 func (i *Integer) GetDebugString() string {
 	return i.Token.Code
 }
 
+func (f *Float) GetCode() string {
+	return f.Token.Code
+}
+
+func (f *Float) GetPosition() token.Position {
+	return f.Token.Position
+}
+
+func (f *Float) GetDebugString() string {
+	return f.Token.Code
+}
+
 func (b *Boolean) GetCode() string {
 	return b.Token.Code
 }
 
+func (b *Boolean) GetPosition() token.Position {
+	return b.Token.Position
+}
+
 func (b *Boolean) GetDebugString() string {
 	return b.Token.Code
 }
 
+func (n *Null) GetCode() string {
+	return n.Token.Code
+}
+
+func (n *Null) GetPosition() token.Position {
+	return n.Token.Position
+}
+
+func (n *Null) GetDebugString() string {
+	return "null"
+}
+
 func (f *Function) GetCode() string {
 	return f.Token.Code
 }
 
+func (f *Function) GetPosition() token.Position {
+	return f.Token.Position
+}
+
 func (f *Function) GetDebugString() string {
 	var out bytes.Buffer
 	params := []string{}
-	for _, param := range f.Parameters {
-		params = append(params, param.GetDebugString())
+	for i, param := range f.Parameters {
+		if f.Defaults != nil && f.Defaults[i] != nil {
+			params = append(params, param.GetDebugString()+"="+f.Defaults[i].GetDebugString())
+		} else {
+			params = append(params, param.GetDebugString())
+		}
+	}
+	if f.Rest != nil {
+		params = append(params, "..."+f.Rest.GetDebugString())
 	}
 	out.WriteString(f.Token.Code)
 	out.WriteString("(")
@@ -301,18 +671,62 @@ func (f *Function) GetDebugString() string {
 	return out.String()
 }
 
+func (s *Spread) GetCode() string {
+	return s.Token.Code
+}
+
+func (s *Spread) GetPosition() token.Position {
+	return s.Token.Position
+}
+
+func (s *Spread) GetDebugString() string {
+	return "..." + s.Expression.GetDebugString()
+}
+
 func (s *String) GetCode() string {
 	return s.Token.Code
 }
 
+func (s *String) GetPosition() token.Position {
+	return s.Token.Position
+}
+
 func (s *String) GetDebugString() string {
 	return s.Token.Code
 }
 
+func (is *InterpolatedString) GetCode() string {
+	return is.Token.Code
+}
+
+func (is *InterpolatedString) GetPosition() token.Position {
+	return is.Token.Position
+}
+
+func (is *InterpolatedString) GetDebugString() string {
+	var out bytes.Buffer
+	out.WriteString("\"")
+	for _, part := range is.Parts {
+		if str, ok := part.(*String); ok {
+			out.WriteString(str.Value)
+		} else {
+			out.WriteString("${")
+			out.WriteString(part.GetDebugString())
+			out.WriteString("}")
+		}
+	}
+	out.WriteString("\"")
+	return out.String()
+}
+
 func (a *Array) GetCode() string {
 	return a.Token.Code
 }
 
+func (a *Array) GetPosition() token.Position {
+	return a.Token.Position
+}
+
 func (a *Array) GetDebugString() string {
 	var out bytes.Buffer
 	elements := []string{}
@@ -329,10 +743,21 @@ func (i *Index) GetCode() string {
 	return i.Token.Code
 }
 
+func (i *Index) GetPosition() token.Position {
+	return i.Token.Position
+}
+
 func (i *Index) GetDebugString() string {
 	var out bytes.Buffer
 	out.WriteString("(")
 	out.WriteString(i.IdentifierExpression.GetDebugString())
+	if i.Optional {
+		out.WriteString("?.")
+		if key, ok := i.IndexExpression.(*String); ok && key.Token.Category == token.Identifier {
+			out.WriteString(key.Value + ")")
+			return out.String()
+		}
+	}
 	out.WriteString("[")
 	out.WriteString(i.IndexExpression.GetDebugString())
 	out.WriteString("])")
@@ -343,11 +768,70 @@ func (h *Hash) GetCode() string {
 	return h.Token.Code
 }
 
+func (h *Hash) GetPosition() token.Position {
+	return h.Token.Position
+}
+
 func (h *Hash) GetDebugString() string {
 	var out bytes.Buffer
 	pairs := []string{}
-	for key, value := range h.Pairs {
-		pairs = append(pairs, key.GetDebugString()+":"+value.GetDebugString())
+	for _, pair := range h.Pairs {
+		pairs = append(pairs, pair.Key.GetDebugString()+":"+pair.Value.GetDebugString())
+	}
+	out.WriteString("{")
+	out.WriteString(strings.Join(pairs, ","))
+	out.WriteString("}")
+	return out.String()
+}
+
+func (w *Wildcard) GetCode() string {
+	return w.Token.Code
+}
+
+func (w *Wildcard) GetPosition() token.Position {
+	return w.Token.Position
+}
+
+func (w *Wildcard) GetDebugString() string {
+	return "_"
+}
+
+func (ap *ArrayPattern) GetCode() string {
+	return ap.Token.Code
+}
+
+func (ap *ArrayPattern) GetPosition() token.Position {
+	return ap.Token.Position
+}
+
+func (ap *ArrayPattern) GetDebugString() string {
+	var out bytes.Buffer
+	elements := []string{}
+	for _, element := range ap.Elements {
+		elements = append(elements, element.GetDebugString())
+	}
+	if ap.Rest != nil {
+		elements = append(elements, "..."+ap.Rest.GetDebugString())
+	}
+	out.WriteString("[")
+	out.WriteString(strings.Join(elements, ","))
+	out.WriteString("]")
+	return out.String()
+}
+
+func (hp *HashPattern) GetCode() string {
+	return hp.Token.Code
+}
+
+func (hp *HashPattern) GetPosition() token.Position {
+	return hp.Token.Position
+}
+
+func (hp *HashPattern) GetDebugString() string {
+	var out bytes.Buffer
+	pairs := []string{}
+	for _, pair := range hp.Pairs {
+		pairs = append(pairs, pair.Key.GetDebugString()+":"+pair.Value.GetDebugString())
 	}
 	out.WriteString("{")
 	out.WriteString(strings.Join(pairs, ","))
@@ -0,0 +1,233 @@
+package ast
+
+////////////////////////////////////////////////////////////////////////////////
+// FUNCTIONS
+////////////////////////////////////////////////////////////////////////////////
+
+func Clone(node Node) Node {
+	switch n := node.(type) {
+	case *Program:
+		return &Program{Statements: cloneStatements(n.Statements)}
+	case *LetStatement:
+		clone := *n
+		clone.Identifier = cloneIdentifier(n.Identifier)
+		clone.Pattern = cloneExpression(n.Pattern)
+		clone.Expression = cloneExpression(n.Expression)
+		return &clone
+	case *ConstStatement:
+		clone := *n
+		clone.Identifier = cloneIdentifier(n.Identifier)
+		clone.Pattern = cloneExpression(n.Pattern)
+		clone.Expression = cloneExpression(n.Expression)
+		return &clone
+	case *ReturnStatement:
+		clone := *n
+		clone.Expression = cloneExpression(n.Expression)
+		return &clone
+	case *WhileStatement:
+		clone := *n
+		clone.Condition = cloneExpression(n.Condition)
+		clone.Body = cloneBlockStatement(n.Body)
+		return &clone
+	case *ForStatement:
+		clone := *n
+		clone.Key = cloneIdentifier(n.Key)
+		clone.Value = cloneIdentifier(n.Value)
+		clone.Iterable = cloneExpression(n.Iterable)
+		clone.Body = cloneBlockStatement(n.Body)
+		return &clone
+	case *BreakStatement:
+		clone := *n
+		return &clone
+	case *ContinueStatement:
+		clone := *n
+		return &clone
+	case *ExpressionStatement:
+		clone := *n
+		clone.Expression = cloneExpression(n.Expression)
+		return &clone
+	case *BlockStatement:
+		return cloneBlockStatement(n)
+	case *PrefixExpression:
+		clone := *n
+		clone.RHSExpression = cloneExpression(n.RHSExpression)
+		return &clone
+	case *InfixExpression:
+		clone := *n
+		clone.LHSExpression = cloneExpression(n.LHSExpression)
+		clone.RHSExpression = cloneExpression(n.RHSExpression)
+		return &clone
+	case *AssignmentExpression:
+		clone := *n
+		clone.Target = cloneExpression(n.Target)
+		clone.Value = cloneExpression(n.Value)
+		return &clone
+	case *IfExpression:
+		clone := *n
+		clone.Condition = cloneExpression(n.Condition)
+		clone.Then = cloneBlockStatement(n.Then)
+		clone.Else = cloneBlockStatement(n.Else)
+		if n.ElseIf != nil {
+			clone.ElseIf, _ = Clone(n.ElseIf).(*IfExpression)
+		}
+		return &clone
+	case *SwitchExpression:
+		clone := *n
+		clone.Subject = cloneExpression(n.Subject)
+		if n.Cases != nil {
+			clone.Cases = make([]*SwitchCase, len(n.Cases))
+			for i, c := range n.Cases {
+				caseClone := *c
+				caseClone.Values = cloneExpressions(c.Values)
+				caseClone.Body = cloneBlockStatement(c.Body)
+				clone.Cases[i] = &caseClone
+			}
+		}
+		return &clone
+	case *MatchExpression:
+		clone := *n
+		clone.Subject = cloneExpression(n.Subject)
+		if n.Arms != nil {
+			clone.Arms = make([]*MatchArm, len(n.Arms))
+			for i, arm := range n.Arms {
+				clone.Arms[i] = &MatchArm{Pattern: cloneExpression(arm.Pattern), Guard: cloneExpression(arm.Guard)}
+				if arm.Body != nil {
+					clone.Arms[i].Body, _ = Clone(arm.Body).(Statement)
+				}
+			}
+		}
+		return &clone
+	case *CallExpression:
+		clone := *n
+		clone.Function = cloneExpression(n.Function)
+		clone.Arguments = cloneExpressions(n.Arguments)
+		return &clone
+	case *Identifier:
+		return cloneIdentifier(n)
+	case *Integer:
+		clone := *n
+		return &clone
+	case *Float:
+		clone := *n
+		return &clone
+	case *Boolean:
+		clone := *n
+		return &clone
+	case *String:
+		clone := *n
+		return &clone
+	case *Null:
+		clone := *n
+		return &clone
+	case *Function:
+		clone := *n
+		if n.Parameters != nil {
+			clone.Parameters = make([]*Identifier, len(n.Parameters))
+			for i, param := range n.Parameters {
+				clone.Parameters[i] = cloneIdentifier(param)
+			}
+		}
+		clone.Defaults = cloneExpressions(n.Defaults)
+		clone.Rest = cloneIdentifier(n.Rest)
+		clone.Body = cloneBlockStatement(n.Body)
+		return &clone
+	case *Spread:
+		clone := *n
+		clone.Expression = cloneExpression(n.Expression)
+		return &clone
+	case *InterpolatedString:
+		clone := *n
+		clone.Parts = cloneExpressions(n.Parts)
+		return &clone
+	case *Array:
+		clone := *n
+		clone.Elements = cloneExpressions(n.Elements)
+		return &clone
+	case *Index:
+		clone := *n
+		clone.IdentifierExpression = cloneExpression(n.IdentifierExpression)
+		clone.IndexExpression = cloneExpression(n.IndexExpression)
+		return &clone
+	case *Hash:
+		clone := *n
+		if n.Pairs != nil {
+			clone.Pairs = make([]*HashPair, len(n.Pairs))
+			for i, pair := range n.Pairs {
+				clone.Pairs[i] = &HashPair{Key: cloneExpression(pair.Key), Value: cloneExpression(pair.Value)}
+			}
+		}
+		return &clone
+	case *Wildcard:
+		clone := *n
+		return &clone
+	case *ArrayPattern:
+		clone := *n
+		if n.Elements != nil {
+			clone.Elements = make([]Pattern, len(n.Elements))
+			for i, element := range n.Elements {
+				clone.Elements[i] = cloneExpression(element)
+			}
+		}
+		clone.Rest = cloneIdentifier(n.Rest)
+		return &clone
+	case *HashPattern:
+		clone := *n
+		if n.Pairs != nil {
+			clone.Pairs = make([]*HashPatternPair, len(n.Pairs))
+			for i, pair := range n.Pairs {
+				clone.Pairs[i] = &HashPatternPair{Key: cloneExpression(pair.Key), Value: cloneExpression(pair.Value)}
+			}
+		}
+		return &clone
+	}
+	return node
+}
+
+func cloneStatements(statements []Statement) []Statement {
+	if statements == nil {
+		return nil
+	}
+	clones := make([]Statement, len(statements))
+	for i, statement := range statements {
+		if statement != nil {
+			clones[i], _ = Clone(statement).(Statement)
+		}
+	}
+	return clones
+}
+
+func cloneExpressions(expressions []Expression) []Expression {
+	if expressions == nil {
+		return nil
+	}
+	clones := make([]Expression, len(expressions))
+	for i, expression := range expressions {
+		clones[i] = cloneExpression(expression)
+	}
+	return clones
+}
+
+func cloneExpression(expression Expression) Expression {
+	if expression == nil {
+		return nil
+	}
+	clone, _ := Clone(expression).(Expression)
+	return clone
+}
+
+func cloneBlockStatement(block *BlockStatement) *BlockStatement {
+	if block == nil {
+		return nil
+	}
+	clone := *block
+	clone.Statements = cloneStatements(block.Statements)
+	return &clone
+}
+
+func cloneIdentifier(identifier *Identifier) *Identifier {
+	if identifier == nil {
+		return nil
+	}
+	clone := *identifier
+	return &clone
+}
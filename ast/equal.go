@@ -0,0 +1,210 @@
+package ast
+
+////////////////////////////////////////////////////////////////////////////////
+// DEPENDENCIES
+////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"reflect"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// FUNCTIONS
+////////////////////////////////////////////////////////////////////////////////
+
+func Equal(a, b Node) bool {
+	if isNilNode(a) || isNilNode(b) {
+		return isNilNode(a) && isNilNode(b)
+	}
+	switch a := a.(type) {
+	case *Program:
+		b, ok := b.(*Program)
+		return ok && equalStatements(a.Statements, b.Statements)
+	case *LetStatement:
+		b, ok := b.(*LetStatement)
+		return ok && Equal(a.Identifier, b.Identifier) && Equal(a.Pattern, b.Pattern) && Equal(a.Expression, b.Expression)
+	case *ConstStatement:
+		b, ok := b.(*ConstStatement)
+		return ok && Equal(a.Identifier, b.Identifier) && Equal(a.Pattern, b.Pattern) && Equal(a.Expression, b.Expression)
+	case *ReturnStatement:
+		b, ok := b.(*ReturnStatement)
+		return ok && Equal(a.Expression, b.Expression)
+	case *WhileStatement:
+		b, ok := b.(*WhileStatement)
+		return ok && Equal(a.Condition, b.Condition) && Equal(a.Body, b.Body)
+	case *ForStatement:
+		b, ok := b.(*ForStatement)
+		return ok && Equal(a.Key, b.Key) && Equal(a.Value, b.Value) && Equal(a.Iterable, b.Iterable) && Equal(a.Body, b.Body)
+	case *BreakStatement:
+		_, ok := b.(*BreakStatement)
+		return ok
+	case *ContinueStatement:
+		_, ok := b.(*ContinueStatement)
+		return ok
+	case *ExpressionStatement:
+		b, ok := b.(*ExpressionStatement)
+		return ok && Equal(a.Expression, b.Expression)
+	case *BlockStatement:
+		b, ok := b.(*BlockStatement)
+		return ok && equalStatements(a.Statements, b.Statements)
+	case *PrefixExpression:
+		b, ok := b.(*PrefixExpression)
+		return ok && a.Operator == b.Operator && Equal(a.RHSExpression, b.RHSExpression)
+	case *InfixExpression:
+		b, ok := b.(*InfixExpression)
+		return ok && a.Operator == b.Operator && Equal(a.LHSExpression, b.LHSExpression) && Equal(a.RHSExpression, b.RHSExpression)
+	case *AssignmentExpression:
+		b, ok := b.(*AssignmentExpression)
+		return ok && a.Operator == b.Operator && Equal(a.Target, b.Target) && Equal(a.Value, b.Value)
+	case *IfExpression:
+		b, ok := b.(*IfExpression)
+		return ok && Equal(a.Condition, b.Condition) && Equal(a.Then, b.Then) && Equal(a.Else, b.Else) && Equal(a.ElseIf, b.ElseIf)
+	case *SwitchExpression:
+		b, ok := b.(*SwitchExpression)
+		if !ok || !Equal(a.Subject, b.Subject) || len(a.Cases) != len(b.Cases) {
+			return false
+		}
+		for i := range a.Cases {
+			if a.Cases[i].Default != b.Cases[i].Default || !equalExpressions(a.Cases[i].Values, b.Cases[i].Values) || !Equal(a.Cases[i].Body, b.Cases[i].Body) {
+				return false
+			}
+		}
+		return true
+	case *MatchExpression:
+		b, ok := b.(*MatchExpression)
+		if !ok || !Equal(a.Subject, b.Subject) || len(a.Arms) != len(b.Arms) {
+			return false
+		}
+		for i := range a.Arms {
+			if !Equal(a.Arms[i].Pattern, b.Arms[i].Pattern) || !Equal(a.Arms[i].Guard, b.Arms[i].Guard) || !Equal(a.Arms[i].Body, b.Arms[i].Body) {
+				return false
+			}
+		}
+		return true
+	case *CallExpression:
+		b, ok := b.(*CallExpression)
+		return ok && Equal(a.Function, b.Function) && equalExpressions(a.Arguments, b.Arguments)
+	case *Identifier:
+		b, ok := b.(*Identifier)
+		return ok && a.Value == b.Value
+	case *Integer:
+		b, ok := b.(*Integer)
+		return ok && a.Value == b.Value
+	case *Float:
+		b, ok := b.(*Float)
+		return ok && a.Value == b.Value
+	case *Boolean:
+		b, ok := b.(*Boolean)
+		return ok && a.Value == b.Value
+	case *String:
+		b, ok := b.(*String)
+		return ok && a.Value == b.Value
+	case *Null:
+		_, ok := b.(*Null)
+		return ok
+	case *Function:
+		b, ok := b.(*Function)
+		if !ok || len(a.Parameters) != len(b.Parameters) {
+			return false
+		}
+		for i := range a.Parameters {
+			if !Equal(a.Parameters[i], b.Parameters[i]) {
+				return false
+			}
+		}
+		return equalExpressions(a.Defaults, b.Defaults) && Equal(a.Rest, b.Rest) && Equal(a.Body, b.Body)
+	case *Spread:
+		b, ok := b.(*Spread)
+		return ok && Equal(a.Expression, b.Expression)
+	case *InterpolatedString:
+		b, ok := b.(*InterpolatedString)
+		return ok && equalExpressions(a.Parts, b.Parts)
+	case *Array:
+		b, ok := b.(*Array)
+		return ok && equalExpressions(a.Elements, b.Elements)
+	case *Index:
+		b, ok := b.(*Index)
+		return ok && a.Optional == b.Optional && Equal(a.IdentifierExpression, b.IdentifierExpression) && Equal(a.IndexExpression, b.IndexExpression)
+	case *Hash:
+		b, ok := b.(*Hash)
+		return ok && equalPairs(a.Pairs, b.Pairs)
+	case *Wildcard:
+		_, ok := b.(*Wildcard)
+		return ok
+	case *ArrayPattern:
+		b, ok := b.(*ArrayPattern)
+		return ok && equalPatterns(a.Elements, b.Elements) && Equal(a.Rest, b.Rest)
+	case *HashPattern:
+		b, ok := b.(*HashPattern)
+		return ok && equalPatternPairs(a.Pairs, b.Pairs)
+	}
+	return false
+}
+
+func equalStatements(a, b []Statement) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !Equal(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func equalExpressions(a, b []Expression) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !Equal(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func equalPairs(a, b []*HashPair) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !Equal(a[i].Key, b[i].Key) || !Equal(a[i].Value, b[i].Value) {
+			return false
+		}
+	}
+	return true
+}
+
+func equalPatterns(a, b []Pattern) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !Equal(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func equalPatternPairs(a, b []*HashPatternPair) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !Equal(a[i].Key, b[i].Key) || !Equal(a[i].Value, b[i].Value) {
+			return false
+		}
+	}
+	return true
+}
+
+func isNilNode(node Node) bool {
+	if node == nil {
+		return true
+	}
+	value := reflect.ValueOf(node)
+	return value.Kind() == reflect.Ptr && value.IsNil()
+}
@@ -0,0 +1,812 @@
+package ast
+
+////////////////////////////////////////////////////////////////////////////////
+// DEPENDENCIES
+////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/klaytonkowalski/example-interpreter/token"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// STRUCTURES
+////////////////////////////////////////////////////////////////////////////////
+
+type jsonObject map[string]interface{}
+
+type jsonFields map[string]json.RawMessage
+
+////////////////////////////////////////////////////////////////////////////////
+// METHODS
+////////////////////////////////////////////////////////////////////////////////
+
+func (f jsonFields) decode(name string, target interface{}) error {
+	raw, ok := f[name]
+	if !ok {
+		return nil
+	}
+	if err := json.Unmarshal(raw, target); err != nil {
+		return fmt.Errorf("field %q: %w", name, err)
+	}
+	return nil
+}
+
+func (f jsonFields) decodeNode(name string, accept func(Node) bool, kind string) (Node, error) {
+	node, err := decodeJSONNode(f[name])
+	if err != nil || node == nil {
+		return nil, err
+	}
+	if !accept(node) {
+		return nil, fmt.Errorf("field %q: %T is not %s", name, node, kind)
+	}
+	return node, nil
+}
+
+func (f jsonFields) requireNode(name string, accept func(Node) bool, kind string) (Node, error) {
+	node, err := f.decodeNode(name, accept, kind)
+	if err == nil && node == nil {
+		return nil, fmt.Errorf("field %q: required", name)
+	}
+	return node, err
+}
+
+func (f jsonFields) decodeNodes(name string, accept func(Node) bool, kind string, required bool) ([]Node, error) {
+	raws := []json.RawMessage{}
+	if err := f.decode(name, &raws); err != nil {
+		return nil, err
+	}
+	nodes := []Node{}
+	for i, raw := range raws {
+		node, err := decodeJSONNode(raw)
+		if err != nil {
+			return nil, err
+		}
+		if node == nil && required {
+			return nil, fmt.Errorf("field %q: element %d required", name, i)
+		}
+		if node != nil && !accept(node) {
+			return nil, fmt.Errorf("field %q: element %d: %T is not %s", name, i, node, kind)
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes, nil
+}
+
+func (f jsonFields) decodeExpression(name string) (Expression, error) {
+	return f.decodeNode(name, isJSONExpression, "an expression")
+}
+
+func (f jsonFields) requireExpression(name string) (Expression, error) {
+	return f.requireNode(name, isJSONExpression, "an expression")
+}
+
+func (f jsonFields) decodeExpressions(name string) ([]Expression, error) {
+	nodes, err := f.decodeNodes(name, isJSONExpression, "an expression", false)
+	return toExpressions(nodes), err
+}
+
+func (f jsonFields) requireExpressions(name string) ([]Expression, error) {
+	nodes, err := f.decodeNodes(name, isJSONExpression, "an expression", true)
+	return toExpressions(nodes), err
+}
+
+func (f jsonFields) requireElements(name string) ([]Expression, error) {
+	nodes, err := f.decodeNodes(name, isJSONElement, "an expression", true)
+	return toExpressions(nodes), err
+}
+
+func (f jsonFields) decodePattern(name string) (Pattern, error) {
+	return f.decodeNode(name, isJSONPattern, "a pattern")
+}
+
+func (f jsonFields) requirePattern(name string) (Pattern, error) {
+	return f.requireNode(name, isJSONPattern, "a pattern")
+}
+
+func (f jsonFields) requirePatterns(name string) ([]Pattern, error) {
+	nodes, err := f.decodeNodes(name, isJSONPattern, "a pattern", true)
+	if err != nil {
+		return nil, err
+	}
+	patterns := []Pattern{}
+	for _, node := range nodes {
+		patterns = append(patterns, node)
+	}
+	return patterns, nil
+}
+
+func (f jsonFields) decodeStatements(name string) ([]Statement, error) {
+	nodes, err := f.decodeNodes(name, isJSONStatement, "a statement", true)
+	if err != nil {
+		return nil, err
+	}
+	statements := []Statement{}
+	for _, node := range nodes {
+		statements = append(statements, node)
+	}
+	return statements, nil
+}
+
+func (f jsonFields) decodeBlock(name string) (*BlockStatement, error) {
+	node, err := decodeJSONNode(f[name])
+	if err != nil || node == nil {
+		return nil, err
+	}
+	block, ok := node.(*BlockStatement)
+	if !ok {
+		return nil, fmt.Errorf("field %q: %T is not a block", name, node)
+	}
+	return block, nil
+}
+
+func (f jsonFields) requireBlock(name string) (*BlockStatement, error) {
+	block, err := f.decodeBlock(name)
+	if err == nil && block == nil {
+		return nil, fmt.Errorf("field %q: required", name)
+	}
+	return block, err
+}
+
+func (f jsonFields) decodeIdentifier(name string) (*Identifier, error) {
+	node, err := decodeJSONNode(f[name])
+	if err != nil || node == nil {
+		return nil, err
+	}
+	identifier, ok := node.(*Identifier)
+	if !ok {
+		return nil, fmt.Errorf("field %q: %T is not an identifier", name, node)
+	}
+	return identifier, nil
+}
+
+func (f jsonFields) requireIdentifier(name string) (*Identifier, error) {
+	identifier, err := f.decodeIdentifier(name)
+	if err == nil && identifier == nil {
+		return nil, fmt.Errorf("field %q: required", name)
+	}
+	return identifier, err
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// FUNCTIONS
+////////////////////////////////////////////////////////////////////////////////
+
+func EncodeJSON(node Node) ([]byte, error) {
+	return json.MarshalIndent(encodeJSONNode(node), "", "  ")
+}
+
+func DecodeJSON(data []byte) (Node, error) {
+	return decodeJSONNode(data)
+}
+
+func DecodeProgramJSON(data []byte) (*Program, error) {
+	node, err := decodeJSONNode(data)
+	if err != nil {
+		return nil, err
+	}
+	program, ok := node.(*Program)
+	if !ok {
+		return nil, fmt.Errorf("expected Program, got %T", node)
+	}
+	return program, nil
+}
+
+func encodeJSONNode(node Node) interface{} {
+	if isNilNode(node) {
+		return nil
+	}
+	switch n := node.(type) {
+	case *Program:
+		return jsonObject{
+			"kind":       "Program",
+			"position":   n.GetPosition(),
+			"statements": encodeJSONStatements(n.Statements),
+		}
+	case *LetStatement:
+		object := createJSONObject("LetStatement", n.LetToken)
+		object["identifier"] = encodeJSONNode(n.Identifier)
+		if n.Pattern != nil {
+			object["pattern"] = encodeJSONNode(n.Pattern)
+		}
+		object["expression"] = encodeJSONNode(n.Expression)
+		if n.Doc != "" {
+			object["doc"] = n.Doc
+		}
+		return object
+	case *ConstStatement:
+		object := createJSONObject("ConstStatement", n.ConstToken)
+		object["identifier"] = encodeJSONNode(n.Identifier)
+		if n.Pattern != nil {
+			object["pattern"] = encodeJSONNode(n.Pattern)
+		}
+		object["expression"] = encodeJSONNode(n.Expression)
+		if n.Doc != "" {
+			object["doc"] = n.Doc
+		}
+		return object
+	case *ReturnStatement:
+		object := createJSONObject("ReturnStatement", n.Token)
+		object["expression"] = encodeJSONNode(n.Expression)
+		return object
+	case *WhileStatement:
+		object := createJSONObject("WhileStatement", n.Token)
+		object["condition"] = encodeJSONNode(n.Condition)
+		object["body"] = encodeJSONNode(n.Body)
+		return object
+	case *ForStatement:
+		object := createJSONObject("ForStatement", n.Token)
+		object["key"] = encodeJSONNode(n.Key)
+		object["value"] = encodeJSONNode(n.Value)
+		object["iterable"] = encodeJSONNode(n.Iterable)
+		object["body"] = encodeJSONNode(n.Body)
+		return object
+	case *BreakStatement:
+		return createJSONObject("BreakStatement", n.Token)
+	case *ContinueStatement:
+		return createJSONObject("ContinueStatement", n.Token)
+	case *ExpressionStatement:
+		object := createJSONObject("ExpressionStatement", n.Token)
+		object["expression"] = encodeJSONNode(n.Expression)
+		return object
+	case *BlockStatement:
+		object := createJSONObject("BlockStatement", n.Token)
+		object["statements"] = encodeJSONStatements(n.Statements)
+		return object
+	case *PrefixExpression:
+		object := createJSONObject("PrefixExpression", n.PrefixToken)
+		object["operator"] = n.Operator
+		object["rhsExpression"] = encodeJSONNode(n.RHSExpression)
+		return object
+	case *InfixExpression:
+		object := createJSONObject("InfixExpression", n.InfixToken)
+		object["operator"] = n.Operator
+		object["lhsExpression"] = encodeJSONNode(n.LHSExpression)
+		object["rhsExpression"] = encodeJSONNode(n.RHSExpression)
+		return object
+	case *AssignmentExpression:
+		object := createJSONObject("AssignmentExpression", n.Token)
+		object["operator"] = n.Operator
+		object["target"] = encodeJSONNode(n.Target)
+		object["value"] = encodeJSONNode(n.Value)
+		return object
+	case *IfExpression:
+		object := createJSONObject("IfExpression", n.IfToken)
+		object["condition"] = encodeJSONNode(n.Condition)
+		object["then"] = encodeJSONNode(n.Then)
+		object["else"] = encodeJSONNode(n.Else)
+		if n.ElseIf != nil {
+			object["elseIf"] = encodeJSONNode(n.ElseIf)
+		}
+		return object
+	case *SwitchExpression:
+		cases := []interface{}{}
+		for _, c := range n.Cases {
+			cases = append(cases, jsonObject{
+				"token":    c.Token,
+				"position": c.Token.Position,
+				"default":  c.Default,
+				"values":   encodeJSONExpressions(c.Values),
+				"body":     encodeJSONNode(c.Body),
+			})
+		}
+		object := createJSONObject("SwitchExpression", n.Token)
+		object["subject"] = encodeJSONNode(n.Subject)
+		object["cases"] = cases
+		return object
+	case *MatchExpression:
+		arms := []interface{}{}
+		for _, arm := range n.Arms {
+			arms = append(arms, jsonObject{
+				"pattern": encodeJSONNode(arm.Pattern),
+				"guard":   encodeJSONNode(arm.Guard),
+				"body":    encodeJSONNode(arm.Body),
+			})
+		}
+		object := createJSONObject("MatchExpression", n.Token)
+		object["subject"] = encodeJSONNode(n.Subject)
+		object["arms"] = arms
+		return object
+	case *CallExpression:
+		object := createJSONObject("CallExpression", n.Token)
+		object["function"] = encodeJSONNode(n.Function)
+		object["arguments"] = encodeJSONExpressions(n.Arguments)
+		return object
+	case *Identifier:
+		object := createJSONObject("Identifier", n.Token)
+		object["value"] = n.Value
+		return object
+	case *Integer:
+		object := createJSONObject("Integer", n.Token)
+		object["value"] = n.Value
+		return object
+	case *Float:
+		object := createJSONObject("Float", n.Token)
+		object["value"] = n.Value
+		return object
+	case *Boolean:
+		object := createJSONObject("Boolean", n.Token)
+		object["value"] = n.Value
+		return object
+	case *String:
+		object := createJSONObject("String", n.Token)
+		object["value"] = n.Value
+		return object
+	case *Null:
+		return createJSONObject("Null", n.Token)
+	case *Function:
+		parameters := []interface{}{}
+		for _, param := range n.Parameters {
+			parameters = append(parameters, encodeJSONNode(param))
+		}
+		object := createJSONObject("Function", n.Token)
+		object["parameters"] = parameters
+		if n.Defaults != nil {
+			object["defaults"] = encodeJSONExpressions(n.Defaults)
+		}
+		if n.Rest != nil {
+			object["rest"] = encodeJSONNode(n.Rest)
+		}
+		object["body"] = encodeJSONNode(n.Body)
+		return object
+	case *Spread:
+		object := createJSONObject("Spread", n.Token)
+		object["expression"] = encodeJSONNode(n.Expression)
+		return object
+	case *InterpolatedString:
+		object := createJSONObject("InterpolatedString", n.Token)
+		object["parts"] = encodeJSONExpressions(n.Parts)
+		return object
+	case *Array:
+		object := createJSONObject("Array", n.Token)
+		object["elements"] = encodeJSONExpressions(n.Elements)
+		return object
+	case *Index:
+		object := createJSONObject("Index", n.Token)
+		object["identifierExpression"] = encodeJSONNode(n.IdentifierExpression)
+		object["indexExpression"] = encodeJSONNode(n.IndexExpression)
+		if n.Optional {
+			object["optional"] = true
+		}
+		return object
+	case *Hash:
+		pairs := []interface{}{}
+		for _, pair := range n.Pairs {
+			pairs = append(pairs, jsonObject{
+				"key":   encodeJSONNode(pair.Key),
+				"value": encodeJSONNode(pair.Value),
+			})
+		}
+		object := createJSONObject("Hash", n.Token)
+		object["pairs"] = pairs
+		return object
+	case *Wildcard:
+		return createJSONObject("Wildcard", n.Token)
+	case *ArrayPattern:
+		elements := []interface{}{}
+		for _, element := range n.Elements {
+			elements = append(elements, encodeJSONNode(element))
+		}
+		object := createJSONObject("ArrayPattern", n.Token)
+		object["elements"] = elements
+		object["rest"] = encodeJSONNode(n.Rest)
+		return object
+	case *HashPattern:
+		pairs := []interface{}{}
+		for _, pair := range n.Pairs {
+			pairs = append(pairs, jsonObject{
+				"key":   encodeJSONNode(pair.Key),
+				"value": encodeJSONNode(pair.Value),
+			})
+		}
+		object := createJSONObject("HashPattern", n.Token)
+		object["pairs"] = pairs
+		return object
+	}
+	return nil
+}
+
+func createJSONObject(kind string, tok token.Token) jsonObject {
+	return jsonObject{
+		"kind":     kind,
+		"token":    tok,
+		"position": tok.Position,
+	}
+}
+
+func encodeJSONStatements(statements []Statement) []interface{} {
+	encoded := []interface{}{}
+	for _, statement := range statements {
+		encoded = append(encoded, encodeJSONNode(statement))
+	}
+	return encoded
+}
+
+func encodeJSONExpressions(expressions []Expression) []interface{} {
+	encoded := []interface{}{}
+	for _, expression := range expressions {
+		encoded = append(encoded, encodeJSONNode(expression))
+	}
+	return encoded
+}
+
+func toExpressions(nodes []Node) []Expression {
+	if nodes == nil {
+		return nil
+	}
+	expressions := []Expression{}
+	for _, node := range nodes {
+		expressions = append(expressions, node)
+	}
+	return expressions
+}
+
+func isJSONExpression(node Node) bool {
+	switch node.(type) {
+	case *Identifier, *Integer, *Float, *Boolean, *String, *Null, *InterpolatedString:
+		return true
+	case *PrefixExpression, *InfixExpression, *AssignmentExpression, *CallExpression, *Index:
+		return true
+	case *IfExpression, *SwitchExpression, *MatchExpression, *Function, *Array, *Hash:
+		return true
+	}
+	return false
+}
+
+func isJSONElement(node Node) bool {
+	_, ok := node.(*Spread)
+	return ok || isJSONExpression(node)
+}
+
+func isJSONPattern(node Node) bool {
+	switch n := node.(type) {
+	case *Identifier, *Wildcard, *ArrayPattern, *HashPattern:
+		return true
+	case *Integer, *Float, *Boolean, *String, *Null:
+		return true
+	case *PrefixExpression:
+		switch n.RHSExpression.(type) {
+		case *Integer, *Float:
+			return n.Operator == "-"
+		}
+	}
+	return false
+}
+
+func isJSONStatement(node Node) bool {
+	switch node.(type) {
+	case *LetStatement, *ConstStatement, *ReturnStatement, *WhileStatement, *ForStatement:
+		return true
+	case *BreakStatement, *ContinueStatement, *ExpressionStatement:
+		return true
+	}
+	return false
+}
+
+func isJSONArmBody(node Node) bool {
+	switch node.(type) {
+	case *BlockStatement, *ExpressionStatement:
+		return true
+	}
+	return false
+}
+
+func decodeJSONNode(data json.RawMessage) (Node, error) {
+	if len(data) == 0 || string(data) == "null" {
+		return nil, nil
+	}
+	fields := jsonFields{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	var kind string
+	var tok token.Token
+	if err := fields.decode("kind", &kind); err != nil {
+		return nil, err
+	}
+	if err := fields.decode("token", &tok); err != nil {
+		return nil, err
+	}
+	var err error
+	switch kind {
+	case "Program":
+		node := &Program{}
+		node.Statements, err = fields.decodeStatements("statements")
+		return node, err
+	case "LetStatement":
+		node := &LetStatement{LetToken: tok}
+		if node.Identifier, err = fields.decodeIdentifier("identifier"); err != nil {
+			return nil, err
+		}
+		if node.Pattern, err = fields.decodePattern("pattern"); err != nil {
+			return nil, err
+		}
+		if node.Identifier == nil && node.Pattern == nil {
+			return nil, fmt.Errorf("field %q: required", "identifier")
+		}
+		if err = fields.decode("doc", &node.Doc); err != nil {
+			return nil, err
+		}
+		node.Expression, err = fields.requireExpression("expression")
+		return node, err
+	case "ConstStatement":
+		node := &ConstStatement{ConstToken: tok}
+		if node.Identifier, err = fields.decodeIdentifier("identifier"); err != nil {
+			return nil, err
+		}
+		if node.Pattern, err = fields.decodePattern("pattern"); err != nil {
+			return nil, err
+		}
+		if node.Identifier == nil && node.Pattern == nil {
+			return nil, fmt.Errorf("field %q: required", "identifier")
+		}
+		if err = fields.decode("doc", &node.Doc); err != nil {
+			return nil, err
+		}
+		node.Expression, err = fields.requireExpression("expression")
+		return node, err
+	case "ReturnStatement":
+		node := &ReturnStatement{Token: tok}
+		node.Expression, err = fields.requireExpression("expression")
+		return node, err
+	case "WhileStatement":
+		node := &WhileStatement{Token: tok}
+		if node.Condition, err = fields.requireExpression("condition"); err != nil {
+			return nil, err
+		}
+		node.Body, err = fields.requireBlock("body")
+		return node, err
+	case "ForStatement":
+		node := &ForStatement{Token: tok}
+		if node.Key, err = fields.decodeIdentifier("key"); err != nil {
+			return nil, err
+		}
+		if node.Value, err = fields.requireIdentifier("value"); err != nil {
+			return nil, err
+		}
+		if node.Iterable, err = fields.requireExpression("iterable"); err != nil {
+			return nil, err
+		}
+		node.Body, err = fields.requireBlock("body")
+		return node, err
+	case "BreakStatement":
+		return &BreakStatement{Token: tok}, nil
+	case "ContinueStatement":
+		return &ContinueStatement{Token: tok}, nil
+	case "ExpressionStatement":
+		node := &ExpressionStatement{Token: tok}
+		node.Expression, err = fields.requireExpression("expression")
+		return node, err
+	case "BlockStatement":
+		node := &BlockStatement{Token: tok}
+		node.Statements, err = fields.decodeStatements("statements")
+		return node, err
+	case "PrefixExpression":
+		node := &PrefixExpression{PrefixToken: tok}
+		if err = fields.decode("operator", &node.Operator); err != nil {
+			return nil, err
+		}
+		node.RHSExpression, err = fields.requireExpression("rhsExpression")
+		return node, err
+	case "InfixExpression":
+		node := &InfixExpression{InfixToken: tok}
+		if err = fields.decode("operator", &node.Operator); err != nil {
+			return nil, err
+		}
+		if node.LHSExpression, err = fields.requireExpression("lhsExpression"); err != nil {
+			return nil, err
+		}
+		node.RHSExpression, err = fields.requireExpression("rhsExpression")
+		return node, err
+	case "AssignmentExpression":
+		node := &AssignmentExpression{Token: tok}
+		if err = fields.decode("operator", &node.Operator); err != nil {
+			return nil, err
+		}
+		if node.Target, err = fields.requireExpression("target"); err != nil {
+			return nil, err
+		}
+		node.Value, err = fields.requireExpression("value")
+		return node, err
+	case "IfExpression":
+		node := &IfExpression{IfToken: tok}
+		if node.Condition, err = fields.requireExpression("condition"); err != nil {
+			return nil, err
+		}
+		if node.Then, err = fields.requireBlock("then"); err != nil {
+			return nil, err
+		}
+		if node.Else, err = fields.decodeBlock("else"); err != nil {
+			return nil, err
+		}
+		elseIf, err := decodeJSONNode(fields["elseIf"])
+		if err != nil {
+			return nil, err
+		}
+		if elseIf != nil {
+			if node.ElseIf, _ = elseIf.(*IfExpression); node.ElseIf == nil {
+				return nil, fmt.Errorf("field %q: %T is not an if expression", "elseIf", elseIf)
+			}
+		}
+		return node, nil
+	case "SwitchExpression":
+		node := &SwitchExpression{Token: tok, Cases: []*SwitchCase{}}
+		if node.Subject, err = fields.requireExpression("subject"); err != nil {
+			return nil, err
+		}
+		cases := []jsonFields{}
+		if err = fields.decode("cases", &cases); err != nil {
+			return nil, err
+		}
+		for _, c := range cases {
+			switchCase := &SwitchCase{}
+			if err = c.decode("token", &switchCase.Token); err != nil {
+				return nil, err
+			}
+			if err = c.decode("default", &switchCase.Default); err != nil {
+				return nil, err
+			}
+			if switchCase.Values, err = c.requireExpressions("values"); err != nil {
+				return nil, err
+			}
+			if switchCase.Body, err = c.requireBlock("body"); err != nil {
+				return nil, err
+			}
+			node.Cases = append(node.Cases, switchCase)
+		}
+		return node, nil
+	case "MatchExpression":
+		node := &MatchExpression{Token: tok, Arms: []*MatchArm{}}
+		if node.Subject, err = fields.requireExpression("subject"); err != nil {
+			return nil, err
+		}
+		arms := []jsonFields{}
+		if err = fields.decode("arms", &arms); err != nil {
+			return nil, err
+		}
+		for _, arm := range arms {
+			pattern, err := arm.requirePattern("pattern")
+			if err != nil {
+				return nil, err
+			}
+			guard, err := arm.decodeExpression("guard")
+			if err != nil {
+				return nil, err
+			}
+			body, err := arm.requireNode("body", isJSONArmBody, "a block or expression statement")
+			if err != nil {
+				return nil, err
+			}
+			node.Arms = append(node.Arms, &MatchArm{Pattern: pattern, Guard: guard, Body: body})
+		}
+		return node, nil
+	case "CallExpression":
+		node := &CallExpression{Token: tok}
+		if node.Function, err = fields.requireExpression("function"); err != nil {
+			return nil, err
+		}
+		node.Arguments, err = fields.requireElements("arguments")
+		return node, err
+	case "Identifier":
+		node := &Identifier{Token: tok}
+		err = fields.decode("value", &node.Value)
+		return node, err
+	case "Integer":
+		node := &Integer{Token: tok}
+		err = fields.decode("value", &node.Value)
+		return node, err
+	case "Float":
+		node := &Float{Token: tok}
+		err = fields.decode("value", &node.Value)
+		return node, err
+	case "Boolean":
+		node := &Boolean{Token: tok}
+		err = fields.decode("value", &node.Value)
+		return node, err
+	case "String":
+		node := &String{Token: tok}
+		err = fields.decode("value", &node.Value)
+		return node, err
+	case "Null":
+		return &Null{Token: tok}, nil
+	case "Function":
+		node := &Function{Token: tok}
+		raws := []json.RawMessage{}
+		if err = fields.decode("parameters", &raws); err != nil {
+			return nil, err
+		}
+		node.Parameters = []*Identifier{}
+		for i := range raws {
+			param, err := jsonFields{"parameter": raws[i]}.requireIdentifier("parameter")
+			if err != nil {
+				return nil, err
+			}
+			node.Parameters = append(node.Parameters, param)
+		}
+		if _, ok := fields["defaults"]; ok {
+			if node.Defaults, err = fields.decodeExpressions("defaults"); err != nil {
+				return nil, err
+			}
+			if len(node.Defaults) != len(node.Parameters) {
+				return nil, fmt.Errorf("field %q: expected %d defaults, got %d", "defaults", len(node.Parameters), len(node.Defaults))
+			}
+		}
+		if node.Rest, err = fields.decodeIdentifier("rest"); err != nil {
+			return nil, err
+		}
+		node.Body, err = fields.requireBlock("body")
+		return node, err
+	case "Spread":
+		node := &Spread{Token: tok}
+		node.Expression, err = fields.requireExpression("expression")
+		return node, err
+	case "InterpolatedString":
+		node := &InterpolatedString{Token: tok}
+		node.Parts, err = fields.requireExpressions("parts")
+		return node, err
+	case "Array":
+		node := &Array{Token: tok}
+		node.Elements, err = fields.requireElements("elements")
+		return node, err
+	case "Index":
+		node := &Index{Token: tok}
+		if node.IdentifierExpression, err = fields.requireExpression("identifierExpression"); err != nil {
+			return nil, err
+		}
+		if node.IndexExpression, err = fields.requireExpression("indexExpression"); err != nil {
+			return nil, err
+		}
+		if _, ok := fields["optional"]; ok {
+			err = fields.decode("optional", &node.Optional)
+		}
+		return node, err
+	case "Hash":
+		node := &Hash{Token: tok, Pairs: []*HashPair{}}
+		pairs := []jsonFields{}
+		if err = fields.decode("pairs", &pairs); err != nil {
+			return nil, err
+		}
+		for _, pair := range pairs {
+			key, err := pair.requireExpression("key")
+			if err != nil {
+				return nil, err
+			}
+			value, err := pair.requireExpression("value")
+			if err != nil {
+				return nil, err
+			}
+			node.Pairs = append(node.Pairs, &HashPair{Key: key, Value: value})
+		}
+		return node, nil
+	case "Wildcard":
+		return &Wildcard{Token: tok}, nil
+	case "ArrayPattern":
+		node := &ArrayPattern{Token: tok}
+		if node.Elements, err = fields.requirePatterns("elements"); err != nil {
+			return nil, err
+		}
+		node.Rest, err = fields.decodeIdentifier("rest")
+		return node, err
+	case "HashPattern":
+		node := &HashPattern{Token: tok, Pairs: []*HashPatternPair{}}
+		pairs := []jsonFields{}
+		if err = fields.decode("pairs", &pairs); err != nil {
+			return nil, err
+		}
+		for _, pair := range pairs {
+			key, err := pair.requireExpression("key")
+			if err != nil {
+				return nil, err
+			}
+			value, err := pair.requirePattern("value")
+			if err != nil {
+				return nil, err
+			}
+			node.Pairs = append(node.Pairs, &HashPatternPair{Key: key, Value: value})
+		}
+		return node, nil
+	}
+	return nil, fmt.Errorf("unknown node kind %q", kind)
+}
@@ -0,0 +1,94 @@
+package ast
+
+////////////////////////////////////////////////////////////////////////////////
+// DEPENDENCIES
+////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"strings"
+	"testing"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// FUNCTIONS
+////////////////////////////////////////////////////////////////////////////////
+
+func TestDecodeRejectsMisplacedNodes(t *testing.T) {
+	tests := []struct {
+		name      string
+		statement string
+		err       string
+	}{
+		{
+			"wildcard as infix operand",
+			`{"kind": "ExpressionStatement", "expression": {"kind": "InfixExpression", "operator": "+",
+				"lhsExpression": {"kind": "Wildcard"}, "rhsExpression": {"kind": "Integer", "value": 1}}}`,
+			`field "lhsExpression": *ast.Wildcard is not an expression`,
+		},
+		{
+			"let statement as call argument",
+			`{"kind": "ExpressionStatement", "expression": {"kind": "CallExpression",
+				"function": {"kind": "Identifier", "value": "puts"},
+				"arguments": [{"kind": "LetStatement", "identifier": {"kind": "Identifier", "value": "a"},
+					"expression": {"kind": "Integer", "value": 1}}]}}`,
+			`field "arguments": element 0: *ast.LetStatement is not an expression`,
+		},
+		{
+			"infix expression as let pattern",
+			`{"kind": "LetStatement", "pattern": {"kind": "InfixExpression", "operator": "+",
+				"lhsExpression": {"kind": "Integer", "value": 1}, "rhsExpression": {"kind": "Integer", "value": 2}},
+				"expression": {"kind": "Integer", "value": 3}}`,
+			`field "pattern": *ast.InfixExpression is not a pattern`,
+		},
+		{
+			"spread outside a list",
+			`{"kind": "ReturnStatement", "expression": {"kind": "Spread", "expression": {"kind": "Array", "elements": []}}}`,
+			`field "expression": *ast.Spread is not an expression`,
+		},
+		{
+			"expression as statement",
+			`{"kind": "Integer", "value": 1}`,
+			`field "statements": element 0: *ast.Integer is not a statement`,
+		},
+		{
+			"let statement as match arm body",
+			`{"kind": "ExpressionStatement", "expression": {"kind": "MatchExpression",
+				"subject": {"kind": "Integer", "value": 1},
+				"arms": [{"pattern": {"kind": "Wildcard"}, "body": {"kind": "LetStatement",
+					"identifier": {"kind": "Identifier", "value": "a"}, "expression": {"kind": "Integer", "value": 1}}}]}}`,
+			`field "body": *ast.LetStatement is not a block or expression statement`,
+		},
+	}
+	for _, test := range tests {
+		data := `{"kind": "Program", "statements": [` + test.statement + `]}`
+		_, err := DecodeProgramJSON([]byte(data))
+		if err == nil {
+			t.Errorf("%s: expected error, got none", test.name)
+			continue
+		}
+		if !strings.Contains(err.Error(), test.err) {
+			t.Errorf("%s: expected error %q, got %q", test.name, test.err, err)
+		}
+	}
+}
+
+func TestDecodeAcceptsPatternsAndSpreads(t *testing.T) {
+	data := `{"kind": "Program", "statements": [
+		{"kind": "LetStatement", "pattern": {"kind": "ArrayPattern", "elements": [
+			{"kind": "Identifier", "value": "a"},
+			{"kind": "Wildcard"},
+			{"kind": "PrefixExpression", "operator": "-", "rhsExpression": {"kind": "Integer", "value": 1}}]},
+			"expression": {"kind": "Array", "elements": [
+				{"kind": "Spread", "expression": {"kind": "Identifier", "value": "b"}}]}}]}`
+	program, err := DecodeProgramJSON([]byte(data))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	let := program.Statements[0].(*LetStatement)
+	if pattern := let.Pattern.(*ArrayPattern); len(pattern.Elements) != 3 {
+		t.Errorf("expected 3 pattern elements, got %d", len(pattern.Elements))
+	}
+	if _, ok := let.Expression.(*Array).Elements[0].(*Spread); !ok {
+		t.Errorf("expected spread element, got %T", let.Expression.(*Array).Elements[0])
+	}
+}
@@ -0,0 +1,323 @@
+package ast
+
+////////////////////////////////////////////////////////////////////////////////
+// INTERFACES
+////////////////////////////////////////////////////////////////////////////////
+
+type Visitor interface {
+	Visit(node Node) Visitor
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// STRUCTURES
+////////////////////////////////////////////////////////////////////////////////
+
+type inspector func(Node) bool
+
+////////////////////////////////////////////////////////////////////////////////
+// METHODS
+////////////////////////////////////////////////////////////////////////////////
+
+func (f inspector) Visit(node Node) Visitor {
+	if f(node) {
+		return f
+	}
+	return nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// FUNCTIONS
+////////////////////////////////////////////////////////////////////////////////
+
+func Walk(v Visitor, node Node) {
+	if v = v.Visit(node); v == nil {
+		return
+	}
+	switch n := node.(type) {
+	case *Program:
+		walkStatements(v, n.Statements)
+	case *LetStatement:
+		if n.Identifier != nil {
+			Walk(v, n.Identifier)
+		}
+		walkExpression(v, n.Pattern)
+		walkExpression(v, n.Expression)
+	case *ConstStatement:
+		if n.Identifier != nil {
+			Walk(v, n.Identifier)
+		}
+		walkExpression(v, n.Pattern)
+		walkExpression(v, n.Expression)
+	case *ReturnStatement:
+		walkExpression(v, n.Expression)
+	case *WhileStatement:
+		walkExpression(v, n.Condition)
+		if n.Body != nil {
+			Walk(v, n.Body)
+		}
+	case *ForStatement:
+		if n.Key != nil {
+			Walk(v, n.Key)
+		}
+		if n.Value != nil {
+			Walk(v, n.Value)
+		}
+		walkExpression(v, n.Iterable)
+		if n.Body != nil {
+			Walk(v, n.Body)
+		}
+	case *ExpressionStatement:
+		walkExpression(v, n.Expression)
+	case *BlockStatement:
+		walkStatements(v, n.Statements)
+	case *PrefixExpression:
+		walkExpression(v, n.RHSExpression)
+	case *InfixExpression:
+		walkExpression(v, n.LHSExpression)
+		walkExpression(v, n.RHSExpression)
+	case *AssignmentExpression:
+		walkExpression(v, n.Target)
+		walkExpression(v, n.Value)
+	case *IfExpression:
+		walkExpression(v, n.Condition)
+		if n.Then != nil {
+			Walk(v, n.Then)
+		}
+		if n.Else != nil {
+			Walk(v, n.Else)
+		}
+		if n.ElseIf != nil {
+			Walk(v, n.ElseIf)
+		}
+	case *SwitchExpression:
+		walkExpression(v, n.Subject)
+		for _, c := range n.Cases {
+			walkExpressions(v, c.Values)
+			if c.Body != nil {
+				Walk(v, c.Body)
+			}
+		}
+	case *MatchExpression:
+		walkExpression(v, n.Subject)
+		for _, arm := range n.Arms {
+			walkExpression(v, arm.Pattern)
+			walkExpression(v, arm.Guard)
+			if arm.Body != nil {
+				Walk(v, arm.Body)
+			}
+		}
+	case *CallExpression:
+		walkExpression(v, n.Function)
+		walkExpressions(v, n.Arguments)
+	case *Function:
+		for _, param := range n.Parameters {
+			Walk(v, param)
+		}
+		walkExpressions(v, n.Defaults)
+		if n.Rest != nil {
+			Walk(v, n.Rest)
+		}
+		if n.Body != nil {
+			Walk(v, n.Body)
+		}
+	case *Spread:
+		walkExpression(v, n.Expression)
+	case *InterpolatedString:
+		walkExpressions(v, n.Parts)
+	case *Array:
+		walkExpressions(v, n.Elements)
+	case *Index:
+		walkExpression(v, n.IdentifierExpression)
+		walkExpression(v, n.IndexExpression)
+	case *Hash:
+		for _, pair := range n.Pairs {
+			walkExpression(v, pair.Key)
+			walkExpression(v, pair.Value)
+		}
+	case *ArrayPattern:
+		for _, element := range n.Elements {
+			walkExpression(v, element)
+		}
+		if n.Rest != nil {
+			Walk(v, n.Rest)
+		}
+	case *HashPattern:
+		for _, pair := range n.Pairs {
+			walkExpression(v, pair.Key)
+			walkExpression(v, pair.Value)
+		}
+	}
+	v.Visit(nil)
+}
+
+func Inspect(node Node, f func(Node) bool) {
+	Walk(inspector(f), node)
+}
+
+// Modify rewrites node bottom-up, replacing each node with the result of f.
+// A nil result removes the node from a statement list; anywhere else, a nil
+// result or a node whose type does not fit the field keeps the original.
+func Modify(node Node, f func(Node) Node) Node {
+	switch n := node.(type) {
+	case *Program:
+		n.Statements = modifyStatements(n.Statements, f)
+	case *LetStatement:
+		n.Identifier = modifyIdentifier(n.Identifier, f)
+		n.Pattern = modifyExpression(n.Pattern, f)
+		n.Expression = modifyExpression(n.Expression, f)
+	case *ConstStatement:
+		n.Identifier = modifyIdentifier(n.Identifier, f)
+		n.Pattern = modifyExpression(n.Pattern, f)
+		n.Expression = modifyExpression(n.Expression, f)
+	case *ReturnStatement:
+		n.Expression = modifyExpression(n.Expression, f)
+	case *WhileStatement:
+		n.Condition = modifyExpression(n.Condition, f)
+		n.Body = modifyBlock(n.Body, f)
+	case *ForStatement:
+		n.Key = modifyIdentifier(n.Key, f)
+		n.Value = modifyIdentifier(n.Value, f)
+		n.Iterable = modifyExpression(n.Iterable, f)
+		n.Body = modifyBlock(n.Body, f)
+	case *ExpressionStatement:
+		n.Expression = modifyExpression(n.Expression, f)
+	case *BlockStatement:
+		n.Statements = modifyStatements(n.Statements, f)
+	case *PrefixExpression:
+		n.RHSExpression = modifyExpression(n.RHSExpression, f)
+	case *InfixExpression:
+		n.LHSExpression = modifyExpression(n.LHSExpression, f)
+		n.RHSExpression = modifyExpression(n.RHSExpression, f)
+	case *AssignmentExpression:
+		n.Target = modifyExpression(n.Target, f)
+		n.Value = modifyExpression(n.Value, f)
+	case *IfExpression:
+		n.Condition = modifyExpression(n.Condition, f)
+		n.Then = modifyBlock(n.Then, f)
+		n.Else = modifyBlock(n.Else, f)
+		if elseIf, ok := modifyExpression(n.ElseIf, f).(*IfExpression); ok {
+			n.ElseIf = elseIf
+		}
+	case *SwitchExpression:
+		n.Subject = modifyExpression(n.Subject, f)
+		for _, c := range n.Cases {
+			c.Values = modifyExpressions(c.Values, f)
+			c.Body = modifyBlock(c.Body, f)
+		}
+	case *MatchExpression:
+		n.Subject = modifyExpression(n.Subject, f)
+		for _, arm := range n.Arms {
+			arm.Pattern = modifyExpression(arm.Pattern, f)
+			arm.Guard = modifyExpression(arm.Guard, f)
+			arm.Body = modifyExpression(arm.Body, f)
+		}
+	case *CallExpression:
+		n.Function = modifyExpression(n.Function, f)
+		n.Arguments = modifyExpressions(n.Arguments, f)
+	case *Function:
+		for i, param := range n.Parameters {
+			n.Parameters[i] = modifyIdentifier(param, f)
+		}
+		n.Defaults = modifyExpressions(n.Defaults, f)
+		n.Rest = modifyIdentifier(n.Rest, f)
+		n.Body = modifyBlock(n.Body, f)
+	case *Spread:
+		n.Expression = modifyExpression(n.Expression, f)
+	case *InterpolatedString:
+		n.Parts = modifyExpressions(n.Parts, f)
+	case *Array:
+		n.Elements = modifyExpressions(n.Elements, f)
+	case *Index:
+		n.IdentifierExpression = modifyExpression(n.IdentifierExpression, f)
+		n.IndexExpression = modifyExpression(n.IndexExpression, f)
+	case *Hash:
+		for _, pair := range n.Pairs {
+			pair.Key = modifyExpression(pair.Key, f)
+			pair.Value = modifyExpression(pair.Value, f)
+		}
+	case *ArrayPattern:
+		for i, element := range n.Elements {
+			n.Elements[i] = modifyExpression(element, f)
+		}
+		n.Rest = modifyIdentifier(n.Rest, f)
+	case *HashPattern:
+		for _, pair := range n.Pairs {
+			pair.Key = modifyExpression(pair.Key, f)
+			pair.Value = modifyExpression(pair.Value, f)
+		}
+	}
+	return f(node)
+}
+
+func walkStatements(v Visitor, statements []Statement) {
+	for _, statement := range statements {
+		if statement != nil {
+			Walk(v, statement)
+		}
+	}
+}
+
+func walkExpressions(v Visitor, expressions []Expression) {
+	for _, expression := range expressions {
+		walkExpression(v, expression)
+	}
+}
+
+func walkExpression(v Visitor, expression Expression) {
+	if expression != nil {
+		Walk(v, expression)
+	}
+}
+
+func modifyStatements(statements []Statement, f func(Node) Node) []Statement {
+	if statements == nil {
+		return nil
+	}
+	modified := statements[:0]
+	for _, statement := range statements {
+		if statement == nil {
+			continue
+		}
+		if statement = Modify(statement, f); !isNilNode(statement) {
+			modified = append(modified, statement)
+		}
+	}
+	return modified
+}
+
+func modifyExpressions(expressions []Expression, f func(Node) Node) []Expression {
+	for i, expression := range expressions {
+		expressions[i] = modifyExpression(expression, f)
+	}
+	return expressions
+}
+
+func modifyExpression(expression Expression, f func(Node) Node) Expression {
+	if isNilNode(expression) {
+		return expression
+	}
+	if modified := Modify(expression, f); !isNilNode(modified) {
+		return modified
+	}
+	return expression
+}
+
+func modifyIdentifier(identifier *Identifier, f func(Node) Node) *Identifier {
+	if identifier == nil {
+		return nil
+	}
+	if modified, ok := Modify(identifier, f).(*Identifier); ok && modified != nil {
+		return modified
+	}
+	return identifier
+}
+
+func modifyBlock(block *BlockStatement, f func(Node) Node) *BlockStatement {
+	if block == nil {
+		return nil
+	}
+	if modified, ok := Modify(block, f).(*BlockStatement); ok && modified != nil {
+		return modified
+	}
+	return block
+}
@@ -0,0 +1,185 @@
+package main
+
+////////////////////////////////////////////////////////////////////////////////
+// DEPENDENCIES
+////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// VARIABLES
+////////////////////////////////////////////////////////////////////////////////
+
+const diffContext = 3
+
+////////////////////////////////////////////////////////////////////////////////
+// STRUCTURES
+////////////////////////////////////////////////////////////////////////////////
+
+type diffLine struct {
+	kind    byte
+	text    string
+	oldLine int
+	newLine int
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// FUNCTIONS
+////////////////////////////////////////////////////////////////////////////////
+
+func createUnifiedDiff(file string, oldText string, newText string) string {
+	lines := compareLines(splitLines(oldText), splitLines(newText))
+	var out bytes.Buffer
+	for start := 0; start < len(lines); {
+		if lines[start].kind == ' ' {
+			start += 1
+			continue
+		}
+		first := start - diffContext
+		if first < 0 {
+			first = 0
+		}
+		last := start
+		for end := start; end < len(lines) && end <= last+2*diffContext; end += 1 {
+			if lines[end].kind != ' ' {
+				last = end
+			}
+		}
+		last += diffContext
+		if last >= len(lines) {
+			last = len(lines) - 1
+		}
+		if out.Len() == 0 {
+			fmt.Fprintf(&out, "--- %s\n+++ %s\n", file, file)
+		}
+		writeHunk(&out, lines[first:last+1])
+		start = last + 1
+	}
+	return out.String()
+}
+
+func writeHunk(out *bytes.Buffer, lines []diffLine) {
+	oldStart, newStart, oldCount, newCount := 0, 0, 0, 0
+	for _, line := range lines {
+		if line.kind != '+' {
+			if oldCount == 0 {
+				oldStart = line.oldLine
+			}
+			oldCount += 1
+		}
+		if line.kind != '-' {
+			if newCount == 0 {
+				newStart = line.newLine
+			}
+			newCount += 1
+		}
+	}
+	fmt.Fprintf(out, "@@ -%d,%d +%d,%d @@\n", oldStart, oldCount, newStart, newCount)
+	for _, line := range lines {
+		fmt.Fprintf(out, "%c%s\n", line.kind, line.text)
+	}
+}
+
+func compareLines(oldLines []string, newLines []string) []diffLine {
+	lines := compareRange(oldLines, newLines, 0, len(oldLines), 0, len(newLines), []diffLine{})
+	for start := 0; start < len(lines); start += 1 {
+		end := start
+		for end < len(lines) && lines[end].kind != ' ' {
+			end += 1
+		}
+		sort.SliceStable(lines[start:end], func(i, j int) bool {
+			return lines[start+i].kind == '-' && lines[start+j].kind == '+'
+		})
+		start = end
+	}
+	return lines
+}
+
+func compareRange(oldLines []string, newLines []string, oldStart, oldEnd, newStart, newEnd int, lines []diffLine) []diffLine {
+	for oldStart < oldEnd && newStart < newEnd && oldLines[oldStart] == newLines[newStart] {
+		lines = append(lines, diffLine{kind: ' ', text: oldLines[oldStart], oldLine: oldStart + 1, newLine: newStart + 1})
+		oldStart += 1
+		newStart += 1
+	}
+	suffix := 0
+	for oldStart < oldEnd-suffix && newStart < newEnd-suffix && oldLines[oldEnd-suffix-1] == newLines[newEnd-suffix-1] {
+		suffix += 1
+	}
+	oldEnd -= suffix
+	newEnd -= suffix
+	switch {
+	case oldStart == oldEnd:
+		for j := newStart; j < newEnd; j += 1 {
+			lines = append(lines, diffLine{kind: '+', text: newLines[j], oldLine: oldStart + 1, newLine: j + 1})
+		}
+	case newStart == newEnd:
+		for i := oldStart; i < oldEnd; i += 1 {
+			lines = append(lines, diffLine{kind: '-', text: oldLines[i], oldLine: i + 1, newLine: newStart + 1})
+		}
+	default:
+		oldMiddle, newMiddle := findMiddleSnake(oldLines[oldStart:oldEnd], newLines[newStart:newEnd])
+		lines = compareRange(oldLines, newLines, oldStart, oldStart+oldMiddle, newStart, newStart+newMiddle, lines)
+		lines = compareRange(oldLines, newLines, oldStart+oldMiddle, oldEnd, newStart+newMiddle, newEnd, lines)
+	}
+	for i := 0; i < suffix; i += 1 {
+		lines = append(lines, diffLine{kind: ' ', text: oldLines[oldEnd+i], oldLine: oldEnd + i + 1, newLine: newEnd + i + 1})
+	}
+	return lines
+}
+
+func findMiddleSnake(oldLines []string, newLines []string) (int, int) {
+	n, m := len(oldLines), len(newLines)
+	delta := n - m
+	odd := delta%2 != 0
+	max := (n + m + 1) / 2
+	offset := max + 1
+	forward := make([]int, 2*max+3)
+	backward := make([]int, 2*max+3)
+	for d := 0; d <= max; d += 1 {
+		for k := -d; k <= d; k += 2 {
+			x := forward[offset+k-1] + 1
+			if k == -d || k != d && forward[offset+k-1] < forward[offset+k+1] {
+				x = forward[offset+k+1]
+			}
+			y := x - k
+			startX, startY := x, y
+			for x < n && y < m && oldLines[x] == newLines[y] {
+				x += 1
+				y += 1
+			}
+			forward[offset+k] = x
+			if c := delta - k; odd && c >= -(d-1) && c <= d-1 && x+backward[offset+c] >= n {
+				return startX, startY
+			}
+		}
+		for c := -d; c <= d; c += 2 {
+			x := backward[offset+c-1] + 1
+			if c == -d || c != d && backward[offset+c-1] < backward[offset+c+1] {
+				x = backward[offset+c+1]
+			}
+			y := x - c
+			startX, startY := x, y
+			for x < n && y < m && oldLines[n-x-1] == newLines[m-y-1] {
+				x += 1
+				y += 1
+			}
+			backward[offset+c] = x
+			if k := delta - c; !odd && k >= -d && k <= d && forward[offset+k]+x >= n {
+				return n - startX, m - startY
+			}
+		}
+	}
+	return n, m
+}
+
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
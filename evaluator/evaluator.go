@@ -5,7 +5,10 @@ package evaluator
 ////////////////////////////////////////////////////////////////////////////////
 
 import (
+	"bytes"
 	"fmt"
+	"math"
+	"strings"
 
 	"github.com/klaytonkowalski/example-interpreter/ast"
 	"github.com/klaytonkowalski/example-interpreter/object"
@@ -26,6 +29,18 @@ var (
 ////////////////////////////////////////////////////////////////////////////////
 
 func Evaluate(node ast.Node, env *object.Environment) object.Object {
+	result := evaluateNode(node, env)
+	if err, ok := result.(*object.Error); ok && !err.Position.IsValid() {
+		err.Position = node.GetPosition()
+	}
+	return result
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// FUNCTIONS
+////////////////////////////////////////////////////////////////////////////////
+
+func evaluateNode(node ast.Node, env *object.Environment) object.Object {
 	switch node := node.(type) {
 	case *ast.Program:
 		return evaluateProgram(node, env)
@@ -33,16 +48,34 @@ func Evaluate(node ast.Node, env *object.Environment) object.Object {
 		return Evaluate(node.Expression, env)
 	case *ast.LetStatement:
 		value := Evaluate(node.Expression, env)
-		if isError(value) {
+		if isError(value) || isSignal(value) {
+			return value
+		}
+		if err := bindObject(env, node.Identifier, node.Pattern, value, false); err != nil {
+			return err
+		}
+	case *ast.ConstStatement:
+		value := Evaluate(node.Expression, env)
+		if isError(value) || isSignal(value) {
 			return value
 		}
-		env.SetObject(node.Identifier.Value, value)
+		if err := bindObject(env, node.Identifier, node.Pattern, value, true); err != nil {
+			return err
+		}
 	case *ast.ReturnStatement:
 		value := Evaluate(node.Expression, env)
-		if isError(value) {
+		if isError(value) || isSignal(value) {
 			return value
 		}
 		return &object.Return{Value: value}
+	case *ast.WhileStatement:
+		return evaluateWhileStatement(node, env)
+	case *ast.ForStatement:
+		return evaluateForStatement(node, env)
+	case *ast.BreakStatement:
+		return &object.Break{Position: node.GetPosition()}
+	case *ast.ContinueStatement:
+		return &object.Continue{Position: node.GetPosition()}
 	case *ast.PrefixExpression:
 		rhsObject := Evaluate(node.RHSExpression, env)
 		if isError(rhsObject) {
@@ -50,6 +83,12 @@ func Evaluate(node ast.Node, env *object.Environment) object.Object {
 		}
 		return evaluatePrefixExpression(node.Operator, rhsObject)
 	case *ast.InfixExpression:
+		if node.Operator == "&&" || node.Operator == "||" {
+			return evaluateLogicalExpression(node, env)
+		}
+		if node.Operator == "??" {
+			return evaluateCoalescingExpression(node, env)
+		}
 		lhsObject := Evaluate(node.LHSExpression, env)
 		if isError(lhsObject) {
 			return lhsObject
@@ -59,20 +98,30 @@ func Evaluate(node ast.Node, env *object.Environment) object.Object {
 			return rhsObject
 		}
 		return evaluateInfixExpression(node.Operator, lhsObject, rhsObject)
+	case *ast.AssignmentExpression:
+		return evaluateAssignmentExpression(node, env)
 	case *ast.BlockStatement:
 		return evaluateBlockStatement(node, env)
 	case *ast.IfExpression:
 		return evaluateIfExpression(node, env)
 	case *ast.Integer:
 		return &object.Integer{Value: node.Value}
+	case *ast.Float:
+		return &object.Float{Value: node.Value}
 	case *ast.Boolean:
 		return convertBoolToBoolean(node.Value)
+	case *ast.Null:
+		return Null
 	case *ast.Identifier:
 		return evaluateIdentifier(node, env)
 	case *ast.Function:
 		params := node.Parameters
 		body := node.Body
-		return &object.Function{Parameters: params, Body: body, Environment: env}
+		return &object.Function{Parameters: params, Defaults: node.Defaults, Rest: node.Rest, Body: body, Environment: env}
+	case *ast.MatchExpression:
+		return evaluateMatchExpression(node, env)
+	case *ast.SwitchExpression:
+		return evaluateSwitchExpression(node, env)
 	case *ast.CallExpression:
 		function := Evaluate(node.Function, env)
 		if isError(function) {
@@ -85,6 +134,8 @@ func Evaluate(node ast.Node, env *object.Environment) object.Object {
 		return applyFunction(function, args)
 	case *ast.String:
 		return &object.String{Value: node.Value}
+	case *ast.InterpolatedString:
+		return evaluateInterpolatedString(node, env)
 	case *ast.Array:
 		elements := evaluateExpressions(node.Elements, env)
 		if len(elements) == 1 && isError(elements[0]) {
@@ -96,6 +147,9 @@ func Evaluate(node ast.Node, env *object.Environment) object.Object {
 		if isError(identifier) {
 			return identifier
 		}
+		if node.Optional && identifier == Null {
+			return Null
+		}
 		index := Evaluate(node.IndexExpression, env)
 		if isError(index) {
 			return index
@@ -107,10 +161,6 @@ func Evaluate(node ast.Node, env *object.Environment) object.Object {
 	return nil
 }
 
-////////////////////////////////////////////////////////////////////////////////
-// FUNCTIONS
-////////////////////////////////////////////////////////////////////////////////
-
 func evaluateProgram(program *ast.Program, env *object.Environment) object.Object {
 	var result object.Object
 	for _, statement := range program.Statements {
@@ -120,6 +170,8 @@ func evaluateProgram(program *ast.Program, env *object.Environment) object.Objec
 			return result.Value
 		case *object.Error:
 			return result
+		case *object.Break, *object.Continue:
+			return createLoopSignalError(result)
 		}
 	}
 	return result
@@ -130,7 +182,7 @@ func evaluateBlockStatement(bs *ast.BlockStatement, env *object.Environment) obj
 	for _, statement := range bs.Statements {
 		result = Evaluate(statement, env)
 		if result != nil {
-			if result.GetType() == object.ObjectReturn || result.GetType() == object.ObjectError {
+			if isSignal(result) || result.GetType() == object.ObjectError {
 				return result
 			}
 		}
@@ -138,12 +190,71 @@ func evaluateBlockStatement(bs *ast.BlockStatement, env *object.Environment) obj
 	return result
 }
 
+func evaluateWhileStatement(ws *ast.WhileStatement, env *object.Environment) object.Object {
+	for {
+		condition := Evaluate(ws.Condition, env)
+		if isError(condition) {
+			return condition
+		}
+		if !isTruthy(condition) {
+			return Null
+		}
+		if result, done := evaluateLoopBody(ws.Body, env); done {
+			return result
+		}
+	}
+}
+
+func evaluateForStatement(fs *ast.ForStatement, env *object.Environment) object.Object {
+	collection := Evaluate(fs.Iterable, env)
+	if isError(collection) {
+		return collection
+	}
+	iterable, ok := collection.(object.Iterable)
+	if !ok {
+		err := createError("Type not iterable: %s", collection.GetType())
+		err.Position = fs.Iterable.GetPosition()
+		return err
+	}
+	iterator := iterable.CreateIterator()
+	for {
+		key, value, ok := iterator.Next()
+		if !ok {
+			return Null
+		}
+		if fs.Key != nil {
+			if err := declareObject(env, fs.Key, key, false); err != nil {
+				return err
+			}
+		}
+		if err := declareObject(env, fs.Value, value, false); err != nil {
+			return err
+		}
+		if result, done := evaluateLoopBody(fs.Body, env); done {
+			return result
+		}
+	}
+}
+
+func evaluateLoopBody(body *ast.BlockStatement, env *object.Environment) (object.Object, bool) {
+	result := Evaluate(body, env)
+	switch result.(type) {
+	case *object.Break:
+		return Null, true
+	case *object.Return, *object.Error:
+		return result, true
+	}
+	return nil, false
+}
+
 func evaluatePrefixExpression(operator string, rhsObject object.Object) object.Object {
 	switch operator {
 	case "!":
 		return evaluateBangExpression(rhsObject)
 	case "-":
 		return evaluateMinusExpression(rhsObject)
+	case "~":
+		return evaluateBitwiseNotExpression(rhsObject)
 	default:
 		return createError("Unknown operator: %s%s", operator, rhsObject.GetType())
 	}
@@ -163,17 +274,30 @@ func evaluateBangExpression(rhsObject object.Object) object.Object {
 }
 
 func evaluateMinusExpression(rhsObject object.Object) object.Object {
-	if rhsObject.GetType() != object.ObjectInteger {
+	switch rhsObject := rhsObject.(type) {
+	case *object.Integer:
+		return &object.Integer{Value: -rhsObject.Value}
+	case *object.Float:
+		return &object.Float{Value: -rhsObject.Value}
+	default:
 		return createError("Wrong expression type: -%s", rhsObject.GetType())
 	}
+}
+
+func evaluateBitwiseNotExpression(rhsObject object.Object) object.Object {
+	if rhsObject.GetType() != object.ObjectInteger {
+		return createError("Wrong expression type: ~%s", rhsObject.GetType())
+	}
 	value := rhsObject.(*object.Integer).Value
-	return &object.Integer{Value: -value}
+	return &object.Integer{Value: ^value}
 }
 
 func evaluateInfixExpression(operator string, lhsObject, rhsObject object.Object) object.Object {
 	switch {
 	case lhsObject.GetType() == object.ObjectInteger && rhsObject.GetType() == object.ObjectInteger:
 		return evaluateIntegerExpression(operator, lhsObject, rhsObject)
+	case isNumber(lhsObject) && isNumber(rhsObject):
+		return evaluateFloatExpression(operator, lhsObject, rhsObject)
 	case operator == "==":
 		return convertBoolToBoolean(lhsObject == rhsObject)
 	case operator == "!=":
@@ -198,11 +322,77 @@ func evaluateIntegerExpression(operator string, lhsObject, rhsObject object.Obje
 	case "*":
 		return &object.Integer{Value: lhsValue * rhsValue}
 	case "/":
+		if rhsValue == 0 {
+			return createError("Division by zero: %d / %d", lhsValue, rhsValue)
+		}
 		return &object.Integer{Value: lhsValue / rhsValue}
+	case "%":
+		if rhsValue == 0 {
+			return createError("Division by zero: %d %% %d", lhsValue, rhsValue)
+		}
+		return &object.Integer{Value: lhsValue % rhsValue}
+	case "**":
+		if rhsValue < 0 {
+			return createError("Negative integer exponent: %d ** %d", lhsValue, rhsValue)
+		}
+		return &object.Integer{Value: raiseIntegerToPower(lhsValue, rhsValue)}
+	case "&":
+		return &object.Integer{Value: lhsValue & rhsValue}
+	case "|":
+		return &object.Integer{Value: lhsValue | rhsValue}
+	case "^":
+		return &object.Integer{Value: lhsValue ^ rhsValue}
+	case "<<":
+		if rhsValue < 0 {
+			return createError("Negative shift count: %d << %d", lhsValue, rhsValue)
+		}
+		return &object.Integer{Value: lhsValue << rhsValue}
+	case ">>":
+		if rhsValue < 0 {
+			return createError("Negative shift count: %d >> %d", lhsValue, rhsValue)
+		}
+		return &object.Integer{Value: lhsValue >> rhsValue}
+	case "<":
+		return convertBoolToBoolean(lhsValue < rhsValue)
+	case ">":
+		return convertBoolToBoolean(lhsValue > rhsValue)
+	case "<=":
+		return convertBoolToBoolean(lhsValue <= rhsValue)
+	case ">=":
+		return convertBoolToBoolean(lhsValue >= rhsValue)
+	case "==":
+		return convertBoolToBoolean(lhsValue == rhsValue)
+	case "!=":
+		return convertBoolToBoolean(lhsValue != rhsValue)
+	default:
+		return createError("Unknown operator: %s %s %s", lhsObject.GetType(), operator, rhsObject.GetType())
+	}
+}
+
+func evaluateFloatExpression(operator string, lhsObject, rhsObject object.Object) object.Object {
+	lhsValue := convertNumberToFloat(lhsObject)
+	rhsValue := convertNumberToFloat(rhsObject)
+	switch operator {
+	case "+":
+		return &object.Float{Value: lhsValue + rhsValue}
+	case "-":
+		return &object.Float{Value: lhsValue - rhsValue}
+	case "*":
+		return &object.Float{Value: lhsValue * rhsValue}
+	case "/":
+		return &object.Float{Value: lhsValue / rhsValue}
+	case "%":
+		return &object.Float{Value: math.Mod(lhsValue, rhsValue)}
+	case "**":
+		return &object.Float{Value: math.Pow(lhsValue, rhsValue)}
 	case "<":
 		return convertBoolToBoolean(lhsValue < rhsValue)
 	case ">":
 		return convertBoolToBoolean(lhsValue > rhsValue)
+	case "<=":
+		return convertBoolToBoolean(lhsValue <= rhsValue)
+	case ">=":
+		return convertBoolToBoolean(lhsValue >= rhsValue)
 	case "==":
 		return convertBoolToBoolean(lhsValue == rhsValue)
 	case "!=":
@@ -212,6 +402,32 @@ func evaluateIntegerExpression(operator string, lhsObject, rhsObject object.Obje
 	}
 }
 
+func evaluateLogicalExpression(node *ast.InfixExpression, env *object.Environment) object.Object {
+	lhsObject := Evaluate(node.LHSExpression, env)
+	if isError(lhsObject) {
+		return lhsObject
+	}
+	if node.Operator == "&&" && !isTruthy(lhsObject) {
+		return False
+	}
+	if node.Operator == "||" && isTruthy(lhsObject) {
+		return True
+	}
+	rhsObject := Evaluate(node.RHSExpression, env)
+	if isError(rhsObject) {
+		return rhsObject
+	}
+	return convertBoolToBoolean(isTruthy(rhsObject))
+}
+
+func evaluateCoalescingExpression(node *ast.InfixExpression, env *object.Environment) object.Object {
+	lhsObject := Evaluate(node.LHSExpression, env)
+	if lhsObject != Null {
+		return lhsObject
+	}
+	return Evaluate(node.RHSExpression, env)
+}
+
 func evaluateIfExpression(ie *ast.IfExpression, env *object.Environment) object.Object {
 	condition := Evaluate(ie.Condition, env)
 	if isError(condition) {
@@ -220,12 +436,166 @@ func evaluateIfExpression(ie *ast.IfExpression, env *object.Environment) object.
 	if isTruthy(condition) {
 		return Evaluate(ie.Then, env)
 	}
+	if ie.ElseIf != nil {
+		return Evaluate(ie.ElseIf, env)
+	}
 	if ie.Else != nil {
 		return Evaluate(ie.Else, env)
 	}
 	return Null
 }
 
+func evaluateAssignmentExpression(node *ast.AssignmentExpression, env *object.Environment) object.Object {
+	switch target := node.Target.(type) {
+	case *ast.Identifier:
+		value := Evaluate(node.Value, env)
+		if isError(value) {
+			return value
+		}
+		if node.Operator != "=" {
+			current := evaluateIdentifier(target, env)
+			if isError(current) {
+				return current
+			}
+			value = evaluateCompoundAssignment(node.Operator, current, value)
+			if isError(value) {
+				return value
+			}
+		}
+		scope, ok := env.GetScope(target.Value)
+		if !ok {
+			return createError("Identifier not found: %s", target.Value)
+		}
+		if scope.IsConstant(target.Value) {
+			return createError("Cannot assign to constant: %s", target.Value)
+		}
+		scope.SetObject(target.Value, value)
+		return value
+	case *ast.Index:
+		collection := Evaluate(target.IdentifierExpression, env)
+		if isError(collection) {
+			return collection
+		}
+		index := Evaluate(target.IndexExpression, env)
+		if isError(index) {
+			return index
+		}
+		value := Evaluate(node.Value, env)
+		if isError(value) {
+			return value
+		}
+		if node.Operator != "=" {
+			current := evaluateIndexExpression(collection, index)
+			if isError(current) {
+				return current
+			}
+			value = evaluateCompoundAssignment(node.Operator, current, value)
+			if isError(value) {
+				return value
+			}
+		}
+		return evaluateIndexAssignment(collection, index, value)
+	default:
+		return createError("Invalid assignment target: %s", node.Target.GetDebugString())
+	}
+}
+
+func evaluateCompoundAssignment(operator string, current, value object.Object) object.Object {
+	return evaluateInfixExpression(strings.TrimSuffix(operator, "="), current, value)
+}
+
+func evaluateIndexAssignment(collection, index, value object.Object) object.Object {
+	switch collection := collection.(type) {
+	case *object.Array:
+		integer, ok := index.(*object.Integer)
+		if !ok {
+			return createError("Array index must be Integer: %s", index.GetType())
+		}
+		if integer.Value < 0 || integer.Value >= int64(len(collection.Elements)) {
+			return createError("Array index out of range: %d", integer.Value)
+		}
+		collection.Elements[integer.Value] = value
+	case *object.Hash:
+		key, ok := index.(object.Hashable)
+		if !ok {
+			return createError("Unusable as hash key: %s", index.GetType())
+		}
+		collection.SetPair(key.GetHashKey(), object.HashPair{Key: index, Value: value})
+	default:
+		return createError("Index assignment not supported: %s", collection.GetType())
+	}
+	return value
+}
+
+func evaluateSwitchExpression(se *ast.SwitchExpression, env *object.Environment) object.Object {
+	subject := Evaluate(se.Subject, env)
+	if isError(subject) {
+		return subject
+	}
+	var fallback *ast.SwitchCase
+	for _, c := range se.Cases {
+		if c.Default {
+			fallback = c
+			continue
+		}
+		for _, value := range c.Values {
+			candidate := Evaluate(value, env)
+			if isError(candidate) {
+				return candidate
+			}
+			if evaluateInfixExpression("==", subject, candidate) == True {
+				return evaluateSwitchCase(c, env)
+			}
+		}
+	}
+	if fallback != nil {
+		return evaluateSwitchCase(fallback, env)
+	}
+	return Null
+}
+
+func evaluateSwitchCase(c *ast.SwitchCase, env *object.Environment) object.Object {
+	result := Evaluate(c.Body, env)
+	if result == nil {
+		return Null
+	}
+	return result
+}
+
+func evaluateMatchExpression(me *ast.MatchExpression, env *object.Environment) object.Object {
+	subject := Evaluate(me.Subject, env)
+	if isError(subject) {
+		return subject
+	}
+	for _, arm := range me.Arms {
+		armEnv := object.CreateClosureEnvironment(env)
+		mismatch, err := matchPattern(armEnv, arm.Pattern, subject, false)
+		if err != nil {
+			return err
+		}
+		if mismatch != nil {
+			continue
+		}
+		if arm.Guard != nil {
+			guard := Evaluate(arm.Guard, armEnv)
+			if isError(guard) {
+				return guard
+			}
+			if !isTruthy(guard) {
+				continue
+			}
+		}
+		result := Evaluate(arm.Body, armEnv)
+		if result == nil {
+			return Null
+		}
+		return result
+	}
+	err := createError("No match arm for value: %s", subject.GetDebugString())
+	err.Position = me.Subject.GetPosition()
+	return err
+}
+
 func evaluateIdentifier(node *ast.Identifier, env *object.Environment) object.Object {
 	if value, ok := env.GetObject(node.Value); ok {
 		return value
@@ -239,6 +609,14 @@ func evaluateIdentifier(node *ast.Identifier, env *object.Environment) object.Ob
 func evaluateExpressions(exps []ast.Expression, env *object.Environment) []object.Object {
 	var result []object.Object
 	for _, exp := range exps {
+		if spread, ok := exp.(*ast.Spread); ok {
+			elements := evaluateSpread(spread, env)
+			if len(elements) == 1 && isError(elements[0]) {
+				return elements
+			}
+			result = append(result, elements...)
+			continue
+		}
 		evaluated := Evaluate(exp, env)
 		if isError(evaluated) {
 			return []object.Object{evaluated}
@@ -248,6 +626,28 @@ func evaluateExpressions(exps []ast.Expression, env *object.Environment) []objec
 	return result
 }
 
+func evaluateSpread(spread *ast.Spread, env *object.Environment) []object.Object {
+	collection := Evaluate(spread.Expression, env)
+	if isError(collection) {
+		return []object.Object{collection}
+	}
+	iterable, ok := collection.(object.Iterable)
+	if !ok {
+		err := createError("Type not iterable: %s", collection.GetType())
+		err.Position = spread.Expression.GetPosition()
+		return []object.Object{err}
+	}
+	elements := []object.Object{}
+	iterator := iterable.CreateIterator()
+	for {
+		_, value, ok := iterator.Next()
+		if !ok {
+			return elements
+		}
+		elements = append(elements, value)
+	}
+}
+
 func evaluateStringExpression(operator string, lhsObject, rhsObject object.Object) object.Object {
 	if operator != "+" {
 		return createError("Unknown operator: %s %s %s", lhsObject.GetType(), operator, rhsObject.GetType())
@@ -257,10 +657,28 @@ func evaluateStringExpression(operator string, lhsObject, rhsObject object.Objec
 	return &object.String{Value: leftVal + rightVal}
 }
 
+func evaluateInterpolatedString(node *ast.InterpolatedString, env *object.Environment) object.Object {
+	var out bytes.Buffer
+	for _, part := range node.Parts {
+		value := Evaluate(part, env)
+		if isError(value) {
+			return value
+		}
+		if str, ok := value.(*object.String); ok {
+			out.WriteString(str.Value)
+		} else {
+			out.WriteString(value.GetDebugString())
+		}
+	}
+	return &object.String{Value: out.String()}
+}
+
 func evaluateIndexExpression(identifier, index object.Object) object.Object {
 	switch {
 	case identifier.GetType() == object.ObjectArray && index.GetType() == object.ObjectInteger:
 		return evaluateArrayIndexExpression(identifier, index)
+	case identifier.GetType() == object.ObjectString && index.GetType() == object.ObjectInteger:
+		return evaluateStringIndexExpression(identifier, index)
 	case identifier.GetType() == object.ObjectHash:
 		return evaluateHashIndexExpression(identifier, index)
 	default:
@@ -278,6 +696,16 @@ func evaluateArrayIndexExpression(identifier, index object.Object) object.Object
 	return array.Elements[indexValue]
 }
 
+func evaluateStringIndexExpression(identifier, index object.Object) object.Object {
+	characters := []rune(identifier.(*object.String).Value)
+	indexValue := index.(*object.Integer).Value
+	max := int64(len(characters) - 1)
+	if indexValue < 0 || indexValue > max {
+		return Null
+	}
+	return &object.String{Value: string(characters[indexValue])}
+}
+
 func evaluateHashIndexExpression(identifier, index object.Object) object.Object {
 	hashObject := identifier.(*object.Hash)
 	key, ok := index.(object.Hashable)
@@ -292,9 +720,9 @@ func evaluateHashIndexExpression(identifier, index object.Object) object.Object
 }
 
 func evaluateHash(node *ast.Hash, env *object.Environment) object.Object {
-	pairs := make(map[object.HashKey]object.HashPair)
-	for keyNode, valueNode := range node.Pairs {
-		key := Evaluate(keyNode, env)
+	hash := object.CreateHash()
+	for _, pair := range node.Pairs {
+		key := Evaluate(pair.Key, env)
 		if isError(key) {
 			return key
 		}
@@ -302,20 +730,22 @@ func evaluateHash(node *ast.Hash, env *object.Environment) object.Object {
 		if !ok {
 			return createError("Unusable as hash key: %s", key.GetType())
 		}
-		value := Evaluate(valueNode, env)
+		value := Evaluate(pair.Value, env)
 		if isError(value) {
 			return value
 		}
-		hashed := hashKey.GetHashKey()
-		pairs[hashed] = object.HashPair{Key: key, Value: value}
+		hash.SetPair(hashKey.GetHashKey(), object.HashPair{Key: key, Value: value})
 	}
-	return &object.Hash{Pairs: pairs}
+	return hash
 }
 
 func applyFunction(fn object.Object, args []object.Object) object.Object {
 	switch fn := fn.(type) {
 	case *object.Function:
-		extendedEnv := extendFunctionEnvironment(fn, args)
+		extendedEnv, err := extendFunctionEnvironment(fn, args)
+		if err != nil {
+			return err
+		}
 		evaluated := Evaluate(fn.Body, extendedEnv)
 		return unwrapReturnValue(evaluated)
 	case *object.Native:
@@ -325,21 +755,200 @@ func applyFunction(fn object.Object, args []object.Object) object.Object {
 	}
 }
 
-func extendFunctionEnvironment(fn *object.Function, args []object.Object) *object.Environment {
+func extendFunctionEnvironment(fn *object.Function, args []object.Object) (*object.Environment, *object.Error) {
+	if err := checkFunctionArity(fn, len(args)); err != nil {
+		return nil, err
+	}
 	env := object.CreateClosureEnvironment(fn.Environment)
 	for i, param := range fn.Parameters {
-		env.SetObject(param.Value, args[i])
+		var value object.Object
+		if i < len(args) {
+			value = args[i]
+		} else {
+			value = Evaluate(fn.Defaults[i], env)
+			if err, ok := value.(*object.Error); ok {
+				return nil, err
+			}
+		}
+		if err := declareObject(env, param, value, false); err != nil {
+			return nil, err
+		}
+	}
+	if fn.Rest != nil {
+		rest := []object.Object{}
+		if len(args) > len(fn.Parameters) {
+			rest = append(rest, args[len(fn.Parameters):]...)
+		}
+		if err := declareObject(env, fn.Rest, &object.Array{Elements: rest}, false); err != nil {
+			return nil, err
+		}
+	}
+	return env, nil
+}
+
+func checkFunctionArity(fn *object.Function, count int) *object.Error {
+	required := len(fn.Parameters)
+	for fn.Defaults != nil && required > 0 && fn.Defaults[required-1] != nil {
+		required -= 1
+	}
+	switch {
+	case fn.Rest != nil && count < required:
+		return createError("Wrong number of arguments; got %d, expected at least %d.", count, required)
+	case fn.Rest != nil:
+		return nil
+	case count >= required && count <= len(fn.Parameters):
+		return nil
+	case required == len(fn.Parameters):
+		return createError("Wrong number of arguments; got %d, expected %d.", count, required)
+	default:
+		return createError("Wrong number of arguments; got %d, expected %d to %d.", count, required, len(fn.Parameters))
+	}
+}
+
+func bindObject(env *object.Environment, identifier *ast.Identifier, pattern ast.Pattern, value object.Object, constant bool) *object.Error {
+	if pattern != nil {
+		return bindPattern(env, pattern, value, constant)
 	}
-	return env
+	return declareObject(env, identifier, value, constant)
+}
+
+func bindPattern(env *object.Environment, pattern ast.Pattern, value object.Object, constant bool) *object.Error {
+	mismatch, err := matchPattern(env, pattern, value, constant)
+	if err != nil {
+		return err
+	}
+	return mismatch
+}
+
+func matchPattern(env *object.Environment, pattern ast.Pattern, value object.Object, constant bool) (mismatch *object.Error, err *object.Error) {
+	switch pattern := pattern.(type) {
+	case *ast.Identifier:
+		return nil, declareObject(env, pattern, value, constant)
+	case *ast.Wildcard:
+		return nil, nil
+	case *ast.ArrayPattern:
+		return matchArrayPattern(env, pattern, value, constant)
+	case *ast.HashPattern:
+		return matchHashPattern(env, pattern, value, constant)
+	}
+	literal := Evaluate(pattern, env)
+	if err, ok := literal.(*object.Error); ok {
+		return nil, err
+	}
+	if !isEqual(literal, value) {
+		return createPatternError(pattern, "Value %s does not match pattern %s", value.GetDebugString(), literal.GetDebugString()), nil
+	}
+	return nil, nil
+}
+
+func matchArrayPattern(env *object.Environment, pattern *ast.ArrayPattern, value object.Object, constant bool) (*object.Error, *object.Error) {
+	array, ok := value.(*object.Array)
+	if !ok {
+		return createPatternError(pattern, "Cannot destructure %s with array pattern", value.GetType()), nil
+	}
+	count := len(pattern.Elements)
+	if pattern.Rest == nil && len(array.Elements) != count {
+		return createPatternError(pattern, "Array pattern expects %d elements, got %d", count, len(array.Elements)), nil
+	}
+	if pattern.Rest != nil && len(array.Elements) < count {
+		return createPatternError(pattern, "Array pattern expects at least %d elements, got %d", count, len(array.Elements)), nil
+	}
+	for i, element := range pattern.Elements {
+		if mismatch, err := matchPattern(env, element, array.Elements[i], constant); mismatch != nil || err != nil {
+			return mismatch, err
+		}
+	}
+	if pattern.Rest != nil {
+		rest := make([]object.Object, len(array.Elements)-count)
+		copy(rest, array.Elements[count:])
+		return nil, declareObject(env, pattern.Rest, &object.Array{Elements: rest}, constant)
+	}
+	return nil, nil
+}
+
+func matchHashPattern(env *object.Environment, pattern *ast.HashPattern, value object.Object, constant bool) (*object.Error, *object.Error) {
+	hash, ok := value.(*object.Hash)
+	if !ok {
+		return createPatternError(pattern, "Cannot destructure %s with hash pattern", value.GetType()), nil
+	}
+	for _, pair := range pattern.Pairs {
+		key := Evaluate(pair.Key, env)
+		if err, ok := key.(*object.Error); ok {
+			return nil, err
+		}
+		hashKey, ok := key.(object.Hashable)
+		if !ok {
+			return nil, createPatternError(pair.Key, "Unusable as hash key: %s", key.GetType())
+		}
+		entry, ok := hash.Pairs[hashKey.GetHashKey()]
+		if !ok {
+			return createPatternError(pair.Key, "Hash pattern key not found: %s", key.GetDebugString()), nil
+		}
+		if mismatch, err := matchPattern(env, pair.Value, entry.Value, constant); mismatch != nil || err != nil {
+			return mismatch, err
+		}
+	}
+	return nil, nil
+}
+
+func createPatternError(node ast.Node, message string, args ...interface{}) *object.Error {
+	err := createError(message, args...)
+	err.Position = node.GetPosition()
+	return err
+}
+
+func declareObject(env *object.Environment, identifier *ast.Identifier, value object.Object, constant bool) *object.Error {
+	var err *object.Error
+	if env.IsConstant(identifier.Value) {
+		err = createError("Cannot redeclare constant: %s", identifier.Value)
+	} else if _, ok := natives[identifier.Value]; ok && env.IsStrict() {
+		err = createError("Cannot shadow native: %s", identifier.Value)
+	}
+	if err != nil {
+		err.Position = identifier.GetPosition()
+		return err
+	}
+	if constant {
+		env.SetConstant(identifier.Value, value)
+	} else {
+		env.SetObject(identifier.Value, value)
+	}
+	return nil
 }
 
 func unwrapReturnValue(obj object.Object) object.Object {
-	if returnValue, ok := obj.(*object.Return); ok {
-		return returnValue
+	switch obj := obj.(type) {
+	case *object.Return:
+		return obj.Value
+	case *object.Break, *object.Continue:
+		return createLoopSignalError(obj)
 	}
 	return obj
 }
 
+func createLoopSignalError(signal object.Object) *object.Error {
+	err := createError("Statement outside loop: %s", signal.GetDebugString())
+	switch signal := signal.(type) {
+	case *object.Break:
+		err.Position = signal.Position
+	case *object.Continue:
+		err.Position = signal.Position
+	}
+	return err
+}
+
+func raiseIntegerToPower(base, exponent int64) int64 {
+	result := int64(1)
+	for exponent > 0 {
+		if exponent&1 == 1 {
+			result *= base
+		}
+		base *= base
+		exponent >>= 1
+	}
+	return result
+}
+
 func convertBoolToBoolean(boolean bool) object.Object {
 	if boolean {
 		return True
@@ -347,6 +956,20 @@ func convertBoolToBoolean(boolean bool) object.Object {
 	return False
 }
 
+func isNumber(obj object.Object) bool {
+	return obj.GetType() == object.ObjectInteger || obj.GetType() == object.ObjectFloat
+}
+
+func convertNumberToFloat(obj object.Object) float64 {
+	switch obj := obj.(type) {
+	case *object.Integer:
+		return float64(obj.Value)
+	case *object.Float:
+		return obj.Value
+	}
+	return 0
+}
+
 func isTruthy(obj object.Object) bool {
 	switch obj {
 	case Null:
@@ -364,6 +987,25 @@ func createError(message string, args ...interface{}) *object.Error {
 	return &object.Error{Message: fmt.Sprintf(message, args...)}
 }
 
+func isEqual(lhsObject, rhsObject object.Object) bool {
+	switch {
+	case isNumber(lhsObject) && isNumber(rhsObject):
+		return evaluateInfixExpression("==", lhsObject, rhsObject) == True
+	case lhsObject.GetType() == object.ObjectString && rhsObject.GetType() == object.ObjectString:
+		return lhsObject.(*object.String).Value == rhsObject.(*object.String).Value
+	default:
+		return lhsObject == rhsObject
+	}
+}
+
+func isSignal(obj object.Object) bool {
+	switch obj.(type) {
+	case *object.Return, *object.Break, *object.Continue:
+		return true
+	}
+	return false
+}
+
 func isError(obj object.Object) bool {
 	if obj != nil {
 		return obj.GetType() == object.ObjectError
@@ -0,0 +1,42 @@
+package evaluator
+
+////////////////////////////////////////////////////////////////////////////////
+// DEPENDENCIES
+////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"testing"
+
+	"github.com/klaytonkowalski/example-interpreter/lexer"
+	"github.com/klaytonkowalski/example-interpreter/object"
+	"github.com/klaytonkowalski/example-interpreter/parser"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// FUNCTIONS
+////////////////////////////////////////////////////////////////////////////////
+
+func TestEmptyArmEvaluatesToNull(t *testing.T) {
+	tests := []struct {
+		script   string
+		expected string
+	}{
+		{"let x = match (1) { 1 => {} }; x;", "null"},
+		{"let x = match (1) { 1 => {} }; x + 1;", "Error at 1:34: Type mismatch: Null + Integer"},
+		{"let x = switch (1) { case 1: }; x;", "null"},
+	}
+	for _, test := range tests {
+		program, err := parser.New(lexer.New(test.script)).ParseProgram()
+		if err != nil {
+			t.Fatalf("%q: %s", test.script, err)
+		}
+		evaluated := Evaluate(program, object.CreateEnvironment())
+		if evaluated == nil {
+			t.Errorf("%q: evaluated to nil", test.script)
+			continue
+		}
+		if debug := evaluated.GetDebugString(); debug != test.expected {
+			t.Errorf("%q: expected %q, got %q", test.script, test.expected, debug)
+		}
+	}
+}
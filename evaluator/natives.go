@@ -2,6 +2,7 @@ package evaluator
 
 import (
 	"fmt"
+	"unicode/utf8"
 
 	"github.com/klaytonkowalski/example-interpreter/object"
 )
@@ -14,7 +15,7 @@ var natives = map[string]*object.Native{
 			}
 			switch arg := args[0].(type) {
 			case *object.String:
-				return &object.Integer{Value: int64(len(arg.Value))}
+				return &object.Integer{Value: int64(utf8.RuneCountInString(arg.Value))}
 			case *object.Array:
 				return &object.Integer{Value: int64(len(arg.Elements))}
 			default:
@@ -86,6 +87,33 @@ var natives = map[string]*object.Native{
 			return &object.Array{Elements: newElements}
 		},
 	},
+	"range": {
+		Function: func(args ...object.Object) object.Object {
+			if len(args) < 1 || len(args) > 3 {
+				return createError("Wrong number of arguments to range(); got %d, expected %d to %d.", len(args), 1, 3)
+			}
+			bounds := []int64{}
+			for _, arg := range args {
+				integer, ok := arg.(*object.Integer)
+				if !ok {
+					return createError("Argument type to range() not supported; got %s, expected %s.", arg.GetType(), object.ObjectInteger)
+				}
+				bounds = append(bounds, integer.Value)
+			}
+			rng := &object.Range{End: bounds[0], Step: 1}
+			if len(bounds) > 1 {
+				rng.Start = bounds[0]
+				rng.End = bounds[1]
+			}
+			if len(bounds) > 2 {
+				rng.Step = bounds[2]
+			}
+			if rng.Step == 0 {
+				return createError("Step argument to range() must not be zero.")
+			}
+			return rng
+		},
+	},
 	"puts": {
 		Function: func(args ...object.Object) object.Object {
 			for _, arg := range args {
@@ -0,0 +1,67 @@
+package main
+
+////////////////////////////////////////////////////////////////////////////////
+// DEPENDENCIES
+////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/klaytonkowalski/example-interpreter/ast"
+	"github.com/klaytonkowalski/example-interpreter/lexer"
+	"github.com/klaytonkowalski/example-interpreter/parser"
+	"github.com/klaytonkowalski/example-interpreter/repl"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// FUNCTIONS
+////////////////////////////////////////////////////////////////////////////////
+
+func runAST(args []string) {
+	flags := flag.NewFlagSet("ast", flag.ExitOnError)
+	load := flags.Bool("run", false, "read a JSON syntax tree and run it instead of exporting a script")
+	strict := flags.Bool("strict", false, "forbid shadowing native functions when running")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "usage: ast [-run [-strict]] [file]")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+	file := "<stdin>"
+	var data []byte
+	var err error
+	if flags.NArg() > 0 {
+		file = flags.Arg(0)
+		data, err = os.ReadFile(file)
+	} else {
+		data, err = io.ReadAll(os.Stdin)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if *load {
+		program, err := ast.DecodeProgramJSON(data)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %s\n", file, err)
+			os.Exit(1)
+		}
+		if !repl.Execute(program, os.Stdout, *strict) {
+			os.Exit(1)
+		}
+		return
+	}
+	program, err := parser.New(lexer.NewFile(file, string(data))).ParseProgram()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	encoded, err := ast.EncodeJSON(program)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	fmt.Println(string(encoded))
+}
@@ -0,0 +1,74 @@
+package main
+
+////////////////////////////////////////////////////////////////////////////////
+// DEPENDENCIES
+////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/klaytonkowalski/example-interpreter/format"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// FUNCTIONS
+////////////////////////////////////////////////////////////////////////////////
+
+func runFormat(args []string) {
+	flags := flag.NewFlagSet("fmt", flag.ExitOnError)
+	write := flags.Bool("w", false, "write the result to the source file instead of standard output")
+	showDiff := flags.Bool("d", false, "print a diff instead of the formatted source")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "usage: fmt [-w] [-d] [file ...]")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+	failed := false
+	if flags.NArg() == 0 {
+		script, err := io.ReadAll(os.Stdin)
+		failed = err != nil || !formatScript("<stdin>", string(script), false, *showDiff)
+	}
+	for _, file := range flags.Args() {
+		script, err := os.ReadFile(file)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			failed = true
+			continue
+		}
+		if !formatScript(file, string(script), *write, *showDiff) {
+			failed = true
+		}
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
+
+func formatScript(file string, script string, write bool, showDiff bool) bool {
+	formatted, err := format.Source(file, script)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return false
+	}
+	if showDiff {
+		fmt.Print(createUnifiedDiff(file, script, formatted))
+	}
+	if write && formatted != script {
+		info, err := os.Stat(file)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return false
+		}
+		if err := os.WriteFile(file, []byte(formatted), info.Mode().Perm()); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return false
+		}
+	}
+	if !write && !showDiff {
+		fmt.Print(formatted)
+	}
+	return true
+}
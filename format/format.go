@@ -0,0 +1,715 @@
+package format
+
+////////////////////////////////////////////////////////////////////////////////
+// DEPENDENCIES
+////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/klaytonkowalski/example-interpreter/ast"
+	"github.com/klaytonkowalski/example-interpreter/lexer"
+	"github.com/klaytonkowalski/example-interpreter/parser"
+	"github.com/klaytonkowalski/example-interpreter/token"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// VARIABLES
+////////////////////////////////////////////////////////////////////////////////
+
+const indentation = "    "
+
+////////////////////////////////////////////////////////////////////////////////
+// STRUCTURES
+////////////////////////////////////////////////////////////////////////////////
+
+type printer struct {
+	out      bytes.Buffer
+	indent   int
+	comments []token.Token
+	comment  int
+	tokens   []token.Token
+	closers  map[int]int
+	line     int
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// METHODS
+////////////////////////////////////////////////////////////////////////////////
+
+func (p *printer) printStatements(statements []ast.Statement, limit int) {
+	for i, statement := range statements {
+		start := statement.GetPosition()
+		next := limit
+		var following ast.Statement
+		if i+1 < len(statements) {
+			following = statements[i+1]
+			next = following.GetPosition().Offset
+		}
+		printed := p.printComments(start.Offset, i == 0)
+		p.printBlankLine(start.Line, i == 0 && !printed)
+		p.writeIndent()
+		p.printStatement(statement, following)
+		end := p.getEndLine(start.Offset, next)
+		p.printTrailingComments(start.Offset, next, end)
+		p.out.WriteString("\n")
+		if end > 0 {
+			p.line = end
+		}
+	}
+	if limit >= 0 {
+		p.printComments(limit, len(statements) == 0)
+	}
+}
+
+func (p *printer) printComments(limit int, first bool) bool {
+	printed := false
+	for p.comment < len(p.comments) && p.comments[p.comment].Position.Offset < limit {
+		comment := p.comments[p.comment]
+		p.printBlankLine(comment.Position.Line, first)
+		first = false
+		p.writeIndent()
+		p.out.WriteString(comment.Code)
+		p.out.WriteString("\n")
+		p.line = comment.Position.Line + strings.Count(comment.Code, "\n")
+		p.comment += 1
+		printed = true
+	}
+	return printed
+}
+
+func (p *printer) printTrailingComments(start, limit, line int) {
+	for p.comment < len(p.comments) {
+		comment := p.comments[p.comment]
+		offset := comment.Position.Offset
+		if offset < start || offset >= limit || comment.Position.Line != line {
+			return
+		}
+		p.out.WriteString(" ")
+		p.out.WriteString(comment.Code)
+		p.comment += 1
+	}
+}
+
+func (p *printer) printInlineComments(limit int) bool {
+	printed := false
+	for p.comment < len(p.comments) && p.comments[p.comment].Position.Offset < limit {
+		comment := p.comments[p.comment]
+		if printed || !p.isTrailing(comment) {
+			p.out.WriteString("\n")
+			p.writeIndent()
+		} else {
+			p.out.WriteString(" ")
+		}
+		p.out.WriteString(comment.Code)
+		p.line = comment.Position.Line + strings.Count(comment.Code, "\n")
+		p.comment += 1
+		printed = true
+	}
+	if printed {
+		p.out.WriteString("\n")
+		p.writeIndent()
+	}
+	return printed
+}
+
+func (p *printer) isTrailing(comment token.Token) bool {
+	index := sort.Search(len(p.tokens), func(i int) bool {
+		return p.tokens[i].Position.Offset >= comment.Position.Offset
+	})
+	if index == 0 {
+		return false
+	}
+	previous := p.tokens[index-1]
+	return previous.Position.Line+strings.Count(previous.Code, "\n") == comment.Position.Line
+}
+
+func (p *printer) getCloser(opener int) int {
+	if closer, ok := p.closers[opener]; ok {
+		return closer
+	}
+	return -1
+}
+
+func (p *printer) getEnclosingCloser(offset int) int {
+	index := sort.Search(len(p.tokens), func(i int) bool {
+		return p.tokens[i].Position.Offset >= offset
+	})
+	if index == 0 {
+		return -1
+	}
+	return p.getCloser(p.tokens[index-1].Position.Offset)
+}
+
+func (p *printer) printBlankLine(line int, first bool) {
+	if !first && p.line > 0 && line > p.line+1 {
+		p.out.WriteString("\n")
+	}
+}
+
+func (p *printer) getEndLine(start, limit int) int {
+	index := sort.Search(len(p.tokens), func(i int) bool {
+		return p.tokens[i].Position.Offset >= limit
+	})
+	if index == 0 || p.tokens[index-1].Position.Offset < start {
+		return 0
+	}
+	last := p.tokens[index-1]
+	return last.Position.Line + strings.Count(last.Code, "\n")
+}
+
+func (p *printer) writeIndent() {
+	p.out.WriteString(strings.Repeat(indentation, p.indent))
+}
+
+func (p *printer) printStatement(statement, following ast.Statement) {
+	switch statement := statement.(type) {
+	case *ast.LetStatement:
+		p.out.WriteString("let ")
+		p.printBinding(statement.Identifier, statement.Pattern)
+		p.out.WriteString(" = ")
+		p.printExpression(statement.Expression)
+		p.out.WriteString(";")
+	case *ast.ConstStatement:
+		p.out.WriteString("const ")
+		p.printBinding(statement.Identifier, statement.Pattern)
+		p.out.WriteString(" = ")
+		p.printExpression(statement.Expression)
+		p.out.WriteString(";")
+	case *ast.ReturnStatement:
+		p.out.WriteString("return ")
+		p.printExpression(statement.Expression)
+		p.out.WriteString(";")
+	case *ast.WhileStatement:
+		p.out.WriteString("while (")
+		p.printExpression(statement.Condition)
+		p.out.WriteString(") ")
+		p.printBlock(statement.Body)
+	case *ast.ForStatement:
+		p.out.WriteString("for (")
+		if statement.Key != nil {
+			p.out.WriteString(statement.Key.Value + ", ")
+		}
+		p.out.WriteString(statement.Value.Value + " in ")
+		p.printExpression(statement.Iterable)
+		p.out.WriteString(") ")
+		p.printBlock(statement.Body)
+	case *ast.BreakStatement:
+		p.out.WriteString("break;")
+	case *ast.ContinueStatement:
+		p.out.WriteString("continue;")
+	case *ast.ExpressionStatement:
+		p.printExpression(statement.Expression)
+		switch statement.Expression.(type) {
+		case *ast.IfExpression, *ast.MatchExpression, *ast.SwitchExpression:
+			if following, ok := following.(*ast.ExpressionStatement); ok && isContinuation(following.Expression) {
+				p.out.WriteString(";")
+			}
+		default:
+			p.out.WriteString(";")
+		}
+	case *ast.BlockStatement:
+		p.printBlock(statement)
+	}
+}
+
+func (p *printer) printBinding(identifier *ast.Identifier, pattern ast.Pattern) {
+	if pattern != nil {
+		p.printPattern(pattern)
+		return
+	}
+	p.out.WriteString(identifier.Value)
+}
+
+func (p *printer) printPattern(pattern ast.Pattern) {
+	switch pattern := pattern.(type) {
+	case *ast.Identifier:
+		p.out.WriteString(pattern.Value)
+	case *ast.Wildcard:
+		p.out.WriteString("_")
+	case *ast.ArrayPattern:
+		p.out.WriteString("[")
+		for i, element := range pattern.Elements {
+			if i > 0 {
+				p.out.WriteString(", ")
+			}
+			p.printPattern(element)
+		}
+		if pattern.Rest != nil {
+			if len(pattern.Elements) > 0 {
+				p.out.WriteString(", ")
+			}
+			p.out.WriteString("..." + pattern.Rest.Value)
+		}
+		p.out.WriteString("]")
+	case *ast.HashPattern:
+		p.out.WriteString("{")
+		for i, pair := range pattern.Pairs {
+			if i > 0 {
+				p.out.WriteString(", ")
+			}
+			key, ok := pair.Key.(*ast.String)
+			identifier, shorthand := pair.Value.(*ast.Identifier)
+			if ok && shorthand && identifier.Value == key.Value && key.Token.Category == token.Identifier {
+				p.out.WriteString(identifier.Value)
+				continue
+			}
+			if ok && key.Token.Category == token.Identifier {
+				p.out.WriteString(key.Value)
+			} else {
+				p.printExpression(pair.Key)
+			}
+			p.out.WriteString(": ")
+			p.printPattern(pair.Value)
+		}
+		p.out.WriteString("}")
+	default:
+		p.printExpression(pattern)
+	}
+}
+
+func (p *printer) printSwitch(switchExpression *ast.SwitchExpression) {
+	p.out.WriteString("switch (")
+	p.printExpression(switchExpression.Subject)
+	p.out.WriteString(") ")
+	if len(switchExpression.Cases) == 0 {
+		p.out.WriteString("{}")
+		return
+	}
+	p.out.WriteString("{\n")
+	p.indent += 1
+	closer := p.getEnclosingCloser(switchExpression.Cases[0].Token.Position.Offset)
+	for i, c := range switchExpression.Cases {
+		p.printComments(c.Token.Position.Offset, i == 0)
+		p.printBlankLine(c.Token.Position.Line, i == 0)
+		p.writeIndent()
+		if c.Default {
+			p.out.WriteString("default:")
+		} else {
+			p.out.WriteString("case ")
+			p.printExpressions(c.Values, -1)
+			p.out.WriteString(":")
+		}
+		p.out.WriteString("\n")
+		p.line = c.Body.Token.Position.Line
+		limit := closer
+		if i+1 < len(switchExpression.Cases) {
+			limit = switchExpression.Cases[i+1].Token.Position.Offset
+		}
+		p.indent += 1
+		p.printStatements(c.Body.Statements, limit)
+		p.indent -= 1
+	}
+	p.indent -= 1
+	p.writeIndent()
+	p.out.WriteString("}")
+}
+
+func (p *printer) printMatch(match *ast.MatchExpression) {
+	p.out.WriteString("match (")
+	p.printExpression(match.Subject)
+	p.out.WriteString(") ")
+	if len(match.Arms) == 0 {
+		p.out.WriteString("{}")
+		return
+	}
+	p.out.WriteString("{\n")
+	p.indent += 1
+	closer := p.getEnclosingCloser(match.Arms[0].Pattern.GetPosition().Offset)
+	for i, arm := range match.Arms {
+		p.printComments(arm.Pattern.GetPosition().Offset, i == 0)
+		p.writeIndent()
+		p.printPattern(arm.Pattern)
+		if arm.Guard != nil {
+			p.out.WriteString(" if ")
+			p.printExpression(arm.Guard)
+		}
+		p.out.WriteString(" => ")
+		switch body := arm.Body.(type) {
+		case *ast.BlockStatement:
+			p.printBlock(body)
+		case *ast.ExpressionStatement:
+			if isHashLeading(body.Expression) {
+				p.out.WriteString("(")
+				p.printExpression(body.Expression)
+				p.out.WriteString(")")
+			} else {
+				p.printExpression(body.Expression)
+			}
+		}
+		p.out.WriteString(",")
+		start := arm.Pattern.GetPosition().Offset
+		next := closer
+		if i+1 < len(match.Arms) {
+			next = match.Arms[i+1].Pattern.GetPosition().Offset
+		}
+		if next >= 0 {
+			end := p.getEndLine(start, next)
+			p.printTrailingComments(start, next, end)
+			if end > 0 {
+				p.line = end
+			}
+		}
+		p.out.WriteString("\n")
+	}
+	if closer >= 0 {
+		p.printComments(closer, false)
+	}
+	p.indent -= 1
+	p.writeIndent()
+	p.out.WriteString("}")
+}
+
+func (p *printer) printBlock(block *ast.BlockStatement) {
+	if len(block.Statements) == 0 && !p.hasComments(block) {
+		p.out.WriteString("{}")
+		return
+	}
+	limit := -1
+	if closer, ok := p.closers[block.Token.Position.Offset]; ok {
+		limit = closer
+	}
+	p.out.WriteString("{\n")
+	p.line = block.Token.Position.Line
+	p.indent += 1
+	p.printStatements(block.Statements, limit)
+	p.indent -= 1
+	p.writeIndent()
+	p.out.WriteString("}")
+}
+
+func (p *printer) hasComments(block *ast.BlockStatement) bool {
+	closer, ok := p.closers[block.Token.Position.Offset]
+	return ok && p.comment < len(p.comments) && p.comments[p.comment].Position.Offset < closer
+}
+
+func (p *printer) printExpression(expression ast.Expression) {
+	switch expression := expression.(type) {
+	case *ast.Identifier:
+		p.out.WriteString(expression.Value)
+	case *ast.Integer:
+		if expression.Token.Code != "" {
+			p.out.WriteString(expression.Token.Code)
+		} else {
+			p.out.WriteString(strconv.FormatInt(expression.Value, 10))
+		}
+	case *ast.Float:
+		if expression.Token.Code != "" {
+			p.out.WriteString(expression.Token.Code)
+		} else {
+			p.out.WriteString(strconv.FormatFloat(expression.Value, 'g', -1, 64))
+		}
+	case *ast.Boolean:
+		p.out.WriteString(strconv.FormatBool(expression.Value))
+	case *ast.Null:
+		p.out.WriteString("null")
+	case *ast.String:
+		p.out.WriteString("\"" + escapeString(expression.Value) + "\"")
+	case *ast.InterpolatedString:
+		p.out.WriteString("\"")
+		for _, part := range expression.Parts {
+			if str, ok := part.(*ast.String); ok {
+				p.out.WriteString(escapeString(str.Value))
+				continue
+			}
+			p.out.WriteString("${")
+			p.printExpression(part)
+			p.out.WriteString("}")
+		}
+		p.out.WriteString("\"")
+	case *ast.PrefixExpression:
+		p.out.WriteString(expression.Operator)
+		p.printOperand(expression.RHSExpression, parser.Prefix, false)
+	case *ast.InfixExpression:
+		precedence := parser.GetPrecedence(expression.InfixToken.Category)
+		rightAssociative := expression.InfixToken.Category == token.DoubleAsterisk
+		p.printOperand(expression.LHSExpression, precedence, rightAssociative)
+		p.out.WriteString(" " + expression.Operator + " ")
+		p.printOperand(expression.RHSExpression, precedence, !rightAssociative)
+	case *ast.AssignmentExpression:
+		p.printOperand(expression.Target, parser.Assign, true)
+		p.out.WriteString(" " + expression.Operator + " ")
+		p.printOperand(expression.Value, parser.Assign, false)
+	case *ast.IfExpression:
+		p.out.WriteString("if (")
+		p.printExpression(expression.Condition)
+		p.out.WriteString(") ")
+		p.printBlock(expression.Then)
+		if expression.ElseIf != nil {
+			p.printElse(expression.ElseIf.IfToken.Position.Offset)
+			p.printExpression(expression.ElseIf)
+		} else if expression.Else != nil {
+			p.printElse(expression.Else.Token.Position.Offset)
+			p.printBlock(expression.Else)
+		}
+	case *ast.SwitchExpression:
+		p.printSwitch(expression)
+	case *ast.Function:
+		p.out.WriteString("fn(")
+		for i, param := range expression.Parameters {
+			if i > 0 {
+				p.out.WriteString(", ")
+			}
+			p.out.WriteString(param.Value)
+			if expression.Defaults != nil && expression.Defaults[i] != nil {
+				p.out.WriteString(" = ")
+				p.printExpression(expression.Defaults[i])
+			}
+		}
+		if expression.Rest != nil {
+			if len(expression.Parameters) > 0 {
+				p.out.WriteString(", ")
+			}
+			p.out.WriteString("..." + expression.Rest.Value)
+		}
+		p.out.WriteString(") ")
+		p.printBlock(expression.Body)
+	case *ast.Spread:
+		p.out.WriteString("...")
+		p.printExpression(expression.Expression)
+	case *ast.MatchExpression:
+		p.printMatch(expression)
+	case *ast.CallExpression:
+		p.printOperand(expression.Function, parser.Call, false)
+		p.out.WriteString("(")
+		p.printExpressions(expression.Arguments, p.getCloser(expression.Token.Position.Offset))
+		p.out.WriteString(")")
+	case *ast.Array:
+		p.out.WriteString("[")
+		p.printExpressions(expression.Elements, p.getCloser(expression.Token.Position.Offset))
+		p.out.WriteString("]")
+	case *ast.Index:
+		p.printOperand(expression.IdentifierExpression, parser.Index, false)
+		if expression.Optional {
+			p.out.WriteString("?.")
+			if key, ok := expression.IndexExpression.(*ast.String); ok && key.Token.Category == token.Identifier {
+				p.out.WriteString(key.Value)
+				break
+			}
+		}
+		p.out.WriteString("[")
+		p.printExpression(expression.IndexExpression)
+		p.out.WriteString("]")
+	case *ast.Hash:
+		p.out.WriteString("{")
+		indent := p.indent
+		for i, pair := range expression.Pairs {
+			if i > 0 {
+				p.printSeparator(getStartOffset(pair.Key), indent)
+			}
+			p.printExpression(pair.Key)
+			p.out.WriteString(": ")
+			p.printExpression(pair.Value)
+		}
+		p.printClosingComments(p.getCloser(expression.Token.Position.Offset), indent)
+		p.out.WriteString("}")
+	}
+}
+
+func (p *printer) printExpressions(expressions []ast.Expression, closer int) {
+	indent := p.indent
+	for i, expression := range expressions {
+		if i > 0 {
+			p.printSeparator(getStartOffset(expression), indent)
+		}
+		p.printExpression(expression)
+	}
+	p.printClosingComments(closer, indent)
+}
+
+func (p *printer) printSeparator(next, indent int) {
+	p.out.WriteString(",")
+	current := p.indent
+	p.indent = indent + 1
+	if !p.printInlineComments(next) {
+		p.indent = current
+		p.out.WriteString(" ")
+	}
+}
+
+func (p *printer) printClosingComments(closer, indent int) {
+	p.indent = indent
+	if closer >= 0 {
+		p.printInlineComments(closer)
+	}
+}
+
+func (p *printer) printElse(next int) {
+	if p.printInlineComments(next) {
+		p.out.WriteString("else ")
+	} else {
+		p.out.WriteString(" else ")
+	}
+}
+
+func (p *printer) printOperand(expression ast.Expression, precedence int, strict bool) {
+	operandPrecedence := getExpressionPrecedence(expression)
+	if operandPrecedence < precedence || strict && operandPrecedence == precedence {
+		p.out.WriteString("(")
+		p.printExpression(expression)
+		p.out.WriteString(")")
+		return
+	}
+	p.printExpression(expression)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// FUNCTIONS
+////////////////////////////////////////////////////////////////////////////////
+
+func Source(file string, script string) (string, error) {
+	program, err := parser.New(lexer.NewFile(file, script)).ParseProgram()
+	if err != nil {
+		return "", err
+	}
+	p := &printer{closers: make(map[int]int)}
+	openers := []int{}
+	lxr := lexer.NewFile(file, script)
+	for lxr.Next() {
+		tok := lxr.Token()
+		switch tok.Category {
+		case token.Comment, token.DocComment:
+			p.comments = append(p.comments, tok)
+			continue
+		case token.LeftBrace, token.LeftParenthesis, token.LeftBracket:
+			openers = append(openers, tok.Position.Offset)
+		case token.RightBrace, token.RightParenthesis, token.RightBracket:
+			if len(openers) > 0 {
+				p.closers[openers[len(openers)-1]] = tok.Position.Offset
+				openers = openers[:len(openers)-1]
+			}
+		}
+		p.tokens = append(p.tokens, tok)
+	}
+	p.printStatements(program.Statements, len(script))
+	return p.out.String(), nil
+}
+
+func Program(program *ast.Program) string {
+	p := &printer{closers: make(map[int]int)}
+	p.printStatements(program.Statements, -1)
+	return p.out.String()
+}
+
+func getExpressionPrecedence(expression ast.Expression) int {
+	switch expression := expression.(type) {
+	case *ast.InfixExpression:
+		return parser.GetPrecedence(expression.InfixToken.Category)
+	case *ast.AssignmentExpression:
+		return parser.Assign
+	case *ast.PrefixExpression:
+		return parser.Prefix
+	case *ast.CallExpression:
+		return parser.Call
+	case *ast.Index:
+		return parser.Index
+	case *ast.IfExpression, *ast.MatchExpression, *ast.SwitchExpression, *ast.Function:
+		return parser.Lowest
+	default:
+		return parser.Index + 1
+	}
+}
+
+func getStartOffset(expression ast.Expression) int {
+	switch expression := expression.(type) {
+	case *ast.InfixExpression:
+		return getStartOffset(expression.LHSExpression)
+	case *ast.AssignmentExpression:
+		return getStartOffset(expression.Target)
+	case *ast.CallExpression:
+		return getStartOffset(expression.Function)
+	case *ast.Index:
+		return getStartOffset(expression.IdentifierExpression)
+	}
+	return expression.GetPosition().Offset
+}
+
+func isContinuation(expression ast.Expression) bool {
+	switch expression := expression.(type) {
+	case *ast.InfixExpression:
+		precedence := parser.GetPrecedence(expression.InfixToken.Category)
+		rightAssociative := expression.InfixToken.Category == token.DoubleAsterisk
+		return isContinuationOperand(expression.LHSExpression, precedence, rightAssociative)
+	case *ast.AssignmentExpression:
+		return isContinuationOperand(expression.Target, parser.Assign, true)
+	case *ast.CallExpression:
+		return isContinuationOperand(expression.Function, parser.Call, false)
+	case *ast.Index:
+		return isContinuationOperand(expression.IdentifierExpression, parser.Index, false)
+	case *ast.PrefixExpression:
+		return expression.Operator == "-"
+	case *ast.Integer:
+		return expression.Token.Code == "" && expression.Value < 0
+	case *ast.Float:
+		return expression.Token.Code == "" && math.Signbit(expression.Value)
+	case *ast.Array:
+		return true
+	}
+	return false
+}
+
+func isContinuationOperand(expression ast.Expression, precedence int, strict bool) bool {
+	operandPrecedence := getExpressionPrecedence(expression)
+	if operandPrecedence < precedence || strict && operandPrecedence == precedence {
+		return true
+	}
+	return isContinuation(expression)
+}
+
+func isHashLeading(expression ast.Expression) bool {
+	switch expression := expression.(type) {
+	case *ast.InfixExpression:
+		precedence := parser.GetPrecedence(expression.InfixToken.Category)
+		rightAssociative := expression.InfixToken.Category == token.DoubleAsterisk
+		return isHashLeadingOperand(expression.LHSExpression, precedence, rightAssociative)
+	case *ast.AssignmentExpression:
+		return isHashLeadingOperand(expression.Target, parser.Assign, true)
+	case *ast.CallExpression:
+		return isHashLeadingOperand(expression.Function, parser.Call, false)
+	case *ast.Index:
+		return isHashLeadingOperand(expression.IdentifierExpression, parser.Index, false)
+	case *ast.Hash:
+		return true
+	}
+	return false
+}
+
+func isHashLeadingOperand(expression ast.Expression, precedence int, strict bool) bool {
+	operandPrecedence := getExpressionPrecedence(expression)
+	if operandPrecedence < precedence || strict && operandPrecedence == precedence {
+		return false
+	}
+	return isHashLeading(expression)
+}
+
+func escapeString(value string) string {
+	var out strings.Builder
+	characters := []rune(value)
+	for i, character := range characters {
+		switch {
+		case character == '"':
+			out.WriteString("\\\"")
+		case character == '\\':
+			out.WriteString("\\\\")
+		case character == '\n':
+			out.WriteString("\\n")
+		case character == '\t':
+			out.WriteString("\\t")
+		case character == '\r':
+			out.WriteString("\\r")
+		case character == '$' && i+1 < len(characters) && characters[i+1] == '{':
+			out.WriteString("\\$")
+		case unicode.IsControl(character):
+			out.WriteString(fmt.Sprintf("\\u{%x}", character))
+		default:
+			out.WriteRune(character)
+		}
+	}
+	return out.String()
+}
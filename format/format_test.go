@@ -0,0 +1,49 @@
+package format
+
+////////////////////////////////////////////////////////////////////////////////
+// DEPENDENCIES
+////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"testing"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// FUNCTIONS
+////////////////////////////////////////////////////////////////////////////////
+
+func TestMatchArmHashRoundTrip(t *testing.T) {
+	tests := []struct {
+		script   string
+		expected string
+	}{
+		{
+			"match (1) { 1 => ({\"a\": 1}) };",
+			"match (1) {\n    1 => ({\"a\": 1}),\n}\n",
+		},
+		{
+			"match (1) { 1 => ({\"a\": 1})[\"a\"], _ => ({\"a\": 1}) == 2 };",
+			"match (1) {\n    1 => ({\"a\": 1}[\"a\"]),\n    _ => ({\"a\": 1} == 2),\n}\n",
+		},
+		{
+			"match (1) { 1 => [{\"a\": 1}], _ => {} };",
+			"match (1) {\n    1 => [{\"a\": 1}],\n    _ => {},\n}\n",
+		},
+	}
+	for _, test := range tests {
+		formatted, err := Source("test", test.script)
+		if err != nil {
+			t.Fatalf("%q: %s", test.script, err)
+		}
+		if formatted != test.expected {
+			t.Errorf("%q: expected %q, got %q", test.script, test.expected, formatted)
+		}
+		reformatted, err := Source("test", formatted)
+		if err != nil {
+			t.Fatalf("%q: formatted source does not parse: %s", formatted, err)
+		}
+		if reformatted != formatted {
+			t.Errorf("%q: not idempotent, got %q", formatted, reformatted)
+		}
+	}
+}
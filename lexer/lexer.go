@@ -5,6 +5,14 @@ package lexer
 ////////////////////////////////////////////////////////////////////////////////
 
 import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
 	"github.com/klaytonkowalski/example-interpreter/token"
 )
 
@@ -13,42 +21,82 @@ import (
 ////////////////////////////////////////////////////////////////////////////////
 
 type Lexer struct {
-	script       string
-	position     int
-	nextPosition int
-	character    byte
+	file           string
+	script         string
+	reader         io.Reader
+	offset         int
+	token          token.Token
+	position       int
+	nextPosition   int
+	character      rune
+	line           int
+	column         int
+	interpolations []int
+	Errors         []*Error
+}
+
+type Error struct {
+	Position token.Position
+	Message  string
 }
 
 ////////////////////////////////////////////////////////////////////////////////
 // METHODS
 ////////////////////////////////////////////////////////////////////////////////
 
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s: %s", e.Position, e.Message)
+}
+
+func (l *Lexer) Next() bool {
+	if l.token.Category == token.End {
+		return false
+	}
+	l.token = l.GetNextToken()
+	return l.token.Category != token.End
+}
+
+func (l *Lexer) Token() token.Token {
+	return l.token
+}
+
 func (l *Lexer) readNextCharacter() {
+	if l.character == '\n' {
+		l.line += 1
+		l.column = 0
+	}
+	l.column += 1
+	l.fillScript(utf8.UTFMax)
+	width := 0
 	if l.nextPosition >= len(l.script) {
 		l.character = 0
 	} else {
-		l.character = l.script[l.nextPosition]
+		l.character, width = utf8.DecodeRuneInString(l.script[l.nextPosition:])
 	}
 	l.position = l.nextPosition
-	l.nextPosition += 1
+	l.nextPosition += width
 }
 
 func (l *Lexer) GetNextToken() token.Token {
 	var tok token.Token
+	l.discardScript()
 	l.readWhitespace()
+	position := l.getPosition()
 	switch l.character {
 	case '=':
 		if l.peekNextCharacter() == '=' {
-			character := l.character
-			l.readNextCharacter()
-			newCode := string(character) + string(l.character)
-			tok.Category = token.IsEqualTo
-			tok.Code = newCode
+			tok = l.readTwoCharacterToken(token.IsEqualTo)
+		} else if l.peekNextCharacter() == '>' {
+			tok = l.readTwoCharacterToken(token.FatArrow)
 		} else {
 			tok = createNewToken(token.Equals, l.character)
 		}
 	case '+':
-		tok = createNewToken(token.Plus, l.character)
+		if l.peekNextCharacter() == '=' {
+			tok = l.readTwoCharacterToken(token.PlusEquals)
+		} else {
+			tok = createNewToken(token.Plus, l.character)
+		}
 	case ',':
 		tok = createNewToken(token.Comma, l.character)
 	case ';':
@@ -58,85 +106,345 @@ func (l *Lexer) GetNextToken() token.Token {
 	case ')':
 		tok = createNewToken(token.RightParenthesis, l.character)
 	case '{':
+		if depth := len(l.interpolations); depth > 0 {
+			l.interpolations[depth-1] += 1
+		}
 		tok = createNewToken(token.LeftBrace, l.character)
 	case '}':
-		tok = createNewToken(token.RightBrace, l.character)
+		if depth := len(l.interpolations); depth > 0 && l.interpolations[depth-1] == 0 {
+			l.interpolations = l.interpolations[:depth-1]
+			tok.Category, tok.Code = l.readString(position, true)
+		} else {
+			if depth > 0 {
+				l.interpolations[depth-1] -= 1
+			}
+			tok = createNewToken(token.RightBrace, l.character)
+		}
 	case '-':
-		tok = createNewToken(token.Minus, l.character)
+		if l.peekNextCharacter() == '=' {
+			tok = l.readTwoCharacterToken(token.MinusEquals)
+		} else {
+			tok = createNewToken(token.Minus, l.character)
+		}
 	case '!':
 		if l.peekNextCharacter() == '=' {
-			character := l.character
-			l.readNextCharacter()
-			newCode := string(character) + string(l.character)
-			tok.Category = token.IsNotEqualTo
-			tok.Code = newCode
+			tok = l.readTwoCharacterToken(token.IsNotEqualTo)
 		} else {
 			tok = createNewToken(token.Bang, l.character)
 		}
 	case '*':
-		tok = createNewToken(token.Asterisk, l.character)
+		if l.peekNextCharacter() == '*' {
+			tok = l.readTwoCharacterToken(token.DoubleAsterisk)
+		} else if l.peekNextCharacter() == '=' {
+			tok = l.readTwoCharacterToken(token.AsteriskEquals)
+		} else {
+			tok = createNewToken(token.Asterisk, l.character)
+		}
+	case '%':
+		tok = createNewToken(token.Percent, l.character)
+	case '&':
+		if l.peekNextCharacter() == '&' {
+			tok = l.readTwoCharacterToken(token.DoubleAmpersand)
+		} else {
+			tok = createNewToken(token.Ampersand, l.character)
+		}
+	case '|':
+		if l.peekNextCharacter() == '|' {
+			tok = l.readTwoCharacterToken(token.DoublePipe)
+		} else {
+			tok = createNewToken(token.Pipe, l.character)
+		}
+	case '?':
+		if l.peekNextCharacter() == '?' {
+			tok = l.readTwoCharacterToken(token.DoubleQuestion)
+		} else if l.peekNextCharacter() == '.' {
+			tok = l.readTwoCharacterToken(token.QuestionDot)
+		} else {
+			tok = createNewToken(token.Illegal, l.character)
+		}
+	case '^':
+		tok = createNewToken(token.Caret, l.character)
+	case '~':
+		tok = createNewToken(token.Tilde, l.character)
 	case '/':
-		tok = createNewToken(token.ForwardSlash, l.character)
+		if l.peekNextCharacter() == '/' {
+			tok.Category, tok.Code = l.readLineComment()
+			tok.Position = position
+			return tok
+		} else if l.peekNextCharacter() == '*' {
+			tok.Category = token.Comment
+			tok.Code = l.readBlockComment(position)
+			tok.Position = position
+			return tok
+		} else if l.peekNextCharacter() == '=' {
+			tok = l.readTwoCharacterToken(token.ForwardSlashEquals)
+		} else {
+			tok = createNewToken(token.ForwardSlash, l.character)
+		}
 	case '<':
-		tok = createNewToken(token.LessThan, l.character)
+		if l.peekNextCharacter() == '=' {
+			tok = l.readTwoCharacterToken(token.LessThanOrEqualTo)
+		} else if l.peekNextCharacter() == '<' {
+			tok = l.readTwoCharacterToken(token.DoubleLessThan)
+		} else {
+			tok = createNewToken(token.LessThan, l.character)
+		}
 	case '>':
-		tok = createNewToken(token.GreaterThan, l.character)
+		if l.peekNextCharacter() == '=' {
+			tok = l.readTwoCharacterToken(token.GreaterThanOrEqualTo)
+		} else if l.peekNextCharacter() == '>' {
+			tok = l.readTwoCharacterToken(token.DoubleGreaterThan)
+		} else {
+			tok = createNewToken(token.GreaterThan, l.character)
+		}
 	case '"':
+		tok.Category, tok.Code = l.readString(position, false)
+	case '`':
 		tok.Category = token.String
-		tok.Code = l.readString()
+		tok.Code = l.readRawString(position)
 	case '[':
 		tok = createNewToken(token.LeftBracket, l.character)
 	case ']':
 		tok = createNewToken(token.RightBracket, l.character)
 	case ':':
 		tok = createNewToken(token.Colon, l.character)
+	case '.':
+		if l.peekCharacter(1) == '.' && l.peekCharacter(2) == '.' {
+			l.readNextCharacter()
+			l.readNextCharacter()
+			tok = token.Token{Category: token.Ellipsis, Code: "..."}
+		} else {
+			tok = createNewToken(token.Illegal, l.character)
+		}
 	case 0:
 		tok.Category = token.End
 	default:
-		if isKeywordOrIdentifierCharacter(l.character) {
+		if isIdentifierStartCharacter(l.character) {
 			code := l.readKeywordOrIdentifier()
 			tok.Category = token.MatchCodeToKeywordOrIdentifier(code)
 			tok.Code = code
+			tok.Position = position
 			return tok
 		} else if isDigit(l.character) {
-			tok.Category = token.Integer
-			tok.Code = l.readInteger()
+			tok.Category, tok.Code = l.readNumber()
+			tok.Position = position
 			return tok
 		} else {
 			tok = createNewToken(token.Illegal, l.character)
 		}
 	}
 	l.readNextCharacter()
+	tok.Position = position
 	return tok
 }
 
+func (l *Lexer) readTwoCharacterToken(category string) token.Token {
+	character := l.character
+	l.readNextCharacter()
+	return token.Token{Category: category, Code: string(character) + string(l.character)}
+}
+
+func (l *Lexer) getPosition() token.Position {
+	return token.Position{File: l.file, Line: l.line, Column: l.column, Offset: l.offset + l.position}
+}
+
+func (l *Lexer) fillScript(size int) {
+	if l.reader == nil || len(l.script)-l.nextPosition >= size {
+		return
+	}
+	buffer := make([]byte, 4096)
+	for l.reader != nil && len(l.script)-l.nextPosition < size {
+		count, err := l.reader.Read(buffer)
+		l.script += string(buffer[:count])
+		if errors.Is(err, io.EOF) {
+			l.reader = nil
+		} else if err != nil {
+			l.appendError(l.getPosition(), err.Error())
+			l.reader = nil
+		}
+	}
+}
+
+func (l *Lexer) discardScript() {
+	if l.reader == nil || l.position == 0 {
+		return
+	}
+	l.script = l.script[l.position:]
+	l.offset += l.position
+	l.nextPosition -= l.position
+	l.position = 0
+}
+
 func (l *Lexer) readKeywordOrIdentifier() string {
 	startPosition := l.position
-	for isKeywordOrIdentifierCharacter(l.character) {
+	for isIdentifierCharacter(l.character) {
 		l.readNextCharacter()
 	}
 	return l.script[startPosition:l.position]
 }
 
 func (l *Lexer) readWhitespace() {
-	for l.character == ' ' || l.character == '\n' || l.character == '\r' {
+	for l.character != 0 && unicode.IsSpace(l.character) {
 		l.readNextCharacter()
 	}
 }
 
-func (l *Lexer) readInteger() string {
+func (l *Lexer) readNumber() (string, string) {
 	startPosition := l.position
-	for isDigit(l.character) {
+	if l.character == '0' && strings.ContainsRune("xXoObB", l.peekNextCharacter()) {
+		l.readNextCharacter()
+		l.readNextCharacter()
+		for isIdentifierCharacter(l.character) {
+			l.readNextCharacter()
+		}
+		return token.Integer, l.script[startPosition:l.position]
+	}
+	category := token.Integer
+	l.readDigits()
+	if l.character == '.' && isDigit(l.peekNextCharacter()) {
+		category = token.Float
+		l.readNextCharacter()
+		l.readDigits()
+	}
+	if l.character == 'e' || l.character == 'E' {
+		next := l.peekNextCharacter()
+		if isDigit(next) || (next == '+' || next == '-') && isDigit(l.peekCharacter(2)) {
+			category = token.Float
+			l.readNextCharacter()
+			if l.character == '+' || l.character == '-' {
+				l.readNextCharacter()
+			}
+			l.readDigits()
+		}
+	}
+	return category, l.script[startPosition:l.position]
+}
+
+func (l *Lexer) readDigits() {
+	for isDigit(l.character) || l.character == '_' {
+		l.readNextCharacter()
+	}
+}
+
+func (l *Lexer) readLineComment() (string, string) {
+	startPosition := l.position
+	for l.character != '\n' && l.character != 0 {
+		l.readNextCharacter()
+	}
+	code := strings.TrimRight(l.script[startPosition:l.position], "\r")
+	if strings.HasPrefix(code, "///") && !strings.HasPrefix(code, "////") {
+		return token.DocComment, code
+	}
+	return token.Comment, code
+}
+
+func (l *Lexer) readBlockComment(position token.Position) string {
+	startPosition := l.position
+	l.readNextCharacter()
+	l.readNextCharacter()
+	for {
+		if l.character == 0 {
+			l.appendError(position, "unterminated block comment")
+			break
+		}
+		if l.character == '*' && l.peekNextCharacter() == '/' {
+			l.readNextCharacter()
+			l.readNextCharacter()
+			break
+		}
 		l.readNextCharacter()
 	}
 	return l.script[startPosition:l.position]
 }
 
-func (l *Lexer) readString() string {
+func (l *Lexer) appendError(position token.Position, message string) {
+	l.Errors = append(l.Errors, &Error{Position: position, Message: message})
+}
+
+func (l *Lexer) readString(position token.Position, continued bool) (string, string) {
+	var out strings.Builder
+	l.readNextCharacter()
+	for l.character != '"' {
+		if l.character == 0 || l.character == '\n' {
+			l.appendError(position, "unterminated string")
+			break
+		}
+		if l.character == '$' && l.peekNextCharacter() == '{' {
+			l.readNextCharacter()
+			l.interpolations = append(l.interpolations, 0)
+			if continued {
+				return token.StringMiddle, out.String()
+			}
+			return token.StringStart, out.String()
+		}
+		if l.character == '\\' {
+			l.readEscapeSequence(&out)
+		} else {
+			out.WriteRune(l.character)
+		}
+		l.readNextCharacter()
+	}
+	if continued {
+		return token.StringEnd, out.String()
+	}
+	return token.String, out.String()
+}
+
+func (l *Lexer) readEscapeSequence(out *strings.Builder) {
+	position := l.getPosition()
+	if next := l.peekNextCharacter(); next == 0 || next == '\n' {
+		return
+	}
+	l.readNextCharacter()
+	switch l.character {
+	case 'n':
+		out.WriteRune('\n')
+	case 't':
+		out.WriteRune('\t')
+	case 'r':
+		out.WriteRune('\r')
+	case '\\', '"', '$':
+		out.WriteRune(l.character)
+	case 'u':
+		l.readUnicodeEscapeSequence(position, out)
+	default:
+		l.appendError(position, fmt.Sprintf("unknown escape sequence \\%c", l.character))
+	}
+}
+
+func (l *Lexer) readUnicodeEscapeSequence(position token.Position, out *strings.Builder) {
+	if l.peekNextCharacter() != '{' {
+		l.appendError(position, "invalid unicode escape sequence; expected \\u{...}")
+		return
+	}
+	l.readNextCharacter()
+	digits := ""
+	for isHexDigit(l.peekNextCharacter()) {
+		l.readNextCharacter()
+		digits += string(l.character)
+	}
+	if l.peekNextCharacter() != '}' || digits == "" || len(digits) > 6 {
+		l.appendError(position, "invalid unicode escape sequence; expected \\u{...}")
+		return
+	}
+	l.readNextCharacter()
+	value, _ := strconv.ParseUint(digits, 16, 32)
+	if !utf8.ValidRune(rune(value)) {
+		l.appendError(position, fmt.Sprintf("invalid unicode code point \\u{%s}", digits))
+		return
+	}
+	out.WriteRune(rune(value))
+}
+
+func (l *Lexer) readRawString(position token.Position) string {
 	startPosition := l.position + 1
 	for {
 		l.readNextCharacter()
-		if l.character == '"' || l.character == 0 {
+		if l.character == '`' {
+			break
+		}
+		if l.character == 0 {
+			l.appendError(position, "unterminated raw string")
 			break
 		}
 	}
@@ -148,26 +456,56 @@ func (l *Lexer) readString() string {
 ////////////////////////////////////////////////////////////////////////////////
 
 func New(script string) *Lexer {
-	lexer_ := &Lexer{script: script}
+	return NewFile("", script)
+}
+
+func NewFile(file string, script string) *Lexer {
+	lexer_ := &Lexer{file: file, script: script, line: 1}
+	lexer_.readNextCharacter()
+	return lexer_
+}
+
+func NewReader(file string, reader io.Reader) *Lexer {
+	lexer_ := &Lexer{file: file, reader: reader, line: 1}
 	lexer_.readNextCharacter()
 	return lexer_
 }
 
-func createNewToken(category string, character byte) token.Token {
+func createNewToken(category string, character rune) token.Token {
 	return token.Token{Category: category, Code: string(character)}
 }
 
-func isKeywordOrIdentifierCharacter(character byte) bool {
-	return 'a' <= character && character <= 'z' || 'A' <= character && character <= 'Z' || character == '_'
+func isIdentifierStartCharacter(character rune) bool {
+	return unicode.IsLetter(character) || character == '_'
 }
 
-func isDigit(character byte) bool {
+func isIdentifierCharacter(character rune) bool {
+	return isIdentifierStartCharacter(character) || unicode.IsDigit(character)
+}
+
+func isDigit(character rune) bool {
 	return '0' <= character && character <= '9'
 }
 
-func (l *Lexer) peekNextCharacter() byte {
-	if l.nextPosition >= len(l.script) {
-		return 0
+func isHexDigit(character rune) bool {
+	return isDigit(character) || 'a' <= character && character <= 'f' || 'A' <= character && character <= 'F'
+}
+
+func (l *Lexer) peekNextCharacter() rune {
+	return l.peekCharacter(1)
+}
+
+func (l *Lexer) peekCharacter(distance int) rune {
+	l.fillScript(distance * utf8.UTFMax)
+	position := l.nextPosition
+	var character rune
+	for ; distance > 0; distance -= 1 {
+		if position >= len(l.script) {
+			return 0
+		}
+		var width int
+		character, width = utf8.DecodeRuneInString(l.script[position:])
+		position += width
 	}
-	return l.script[l.nextPosition]
+	return character
 }
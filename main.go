@@ -17,10 +17,39 @@ import (
 ////////////////////////////////////////////////////////////////////////////////
 
 func main() {
+	args := os.Args[1:]
+	strict := len(args) > 0 && args[0] == "-strict"
+	if strict {
+		args = args[1:]
+	}
+	if len(args) > 0 {
+		switch args[0] {
+		case "tokens":
+			runTokens(args[1:])
+		case "fmt":
+			runFormat(args[1:])
+		case "ast":
+			runAST(args[1:])
+		default:
+			runScript(args[0], strict)
+		}
+		return
+	}
 	user, err := user.Current()
 	if err != nil {
 		panic(err)
 	}
 	fmt.Printf("Bonvenon, %s. This is the Monkey programming language.\n", user.Username)
-	repl.Start(os.Stdin, os.Stdout)
+	repl.Start(os.Stdin, os.Stdout, strict)
+}
+
+func runScript(file string, strict bool) {
+	script, err := os.ReadFile(file)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if !repl.Run(file, string(script), os.Stdout, strict) {
+		os.Exit(1)
+	}
 }
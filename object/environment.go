@@ -5,8 +5,10 @@ package object
 ////////////////////////////////////////////////////////////////////////////////
 
 type Environment struct {
-	store  map[string]Object
-	parent *Environment
+	store     map[string]Object
+	constants map[string]bool
+	parent    *Environment
+	strict    bool
 }
 
 ////////////////////////////////////////////////////////////////////////////////
@@ -26,13 +28,46 @@ func (e *Environment) SetObject(key string, obj Object) Object {
 	return obj
 }
 
+func (e *Environment) SetConstant(key string, obj Object) Object {
+	e.store[key] = obj
+	e.constants[key] = true
+	return obj
+}
+
+func (e *Environment) IsConstant(key string) bool {
+	return e.constants[key]
+}
+
+func (e *Environment) GetScope(key string) (*Environment, bool) {
+	if _, ok := e.store[key]; ok {
+		return e, true
+	}
+	if e.parent != nil {
+		return e.parent.GetScope(key)
+	}
+	return nil, false
+}
+
+func (e *Environment) IsStrict() bool {
+	if e.parent != nil {
+		return e.parent.IsStrict()
+	}
+	return e.strict
+}
+
 ////////////////////////////////////////////////////////////////////////////////
 // FUNCTIONS
 ////////////////////////////////////////////////////////////////////////////////
 
 func CreateEnvironment() *Environment {
 	m := make(map[string]Object)
-	return &Environment{store: m, parent: nil}
+	return &Environment{store: m, constants: make(map[string]bool), parent: nil}
+}
+
+func CreateStrictEnvironment() *Environment {
+	env := CreateEnvironment()
+	env.strict = true
+	return env
 }
 
 func CreateClosureEnvironment(parent *Environment) *Environment {
@@ -0,0 +1,118 @@
+package object
+
+////////////////////////////////////////////////////////////////////////////////
+// DEPENDENCIES
+////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"math"
+	"unicode/utf8"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// INTERFACES
+////////////////////////////////////////////////////////////////////////////////
+
+type Iterable interface {
+	CreateIterator() Iterator
+}
+
+type Iterator interface {
+	Next() (Object, Object, bool)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// STRUCTURES
+////////////////////////////////////////////////////////////////////////////////
+
+type arrayIterator struct {
+	elements []Object
+	index    int
+}
+
+type hashIterator struct {
+	hash  *Hash
+	keys  []HashKey
+	index int
+}
+
+type stringIterator struct {
+	value  string
+	offset int
+	index  int
+}
+
+type rangeIterator struct {
+	current int64
+	end     int64
+	step    int64
+	index   int
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// METHODS
+////////////////////////////////////////////////////////////////////////////////
+
+func (a *Array) CreateIterator() Iterator {
+	return &arrayIterator{elements: a.Elements}
+}
+
+func (h *Hash) CreateIterator() Iterator {
+	return &hashIterator{hash: h, keys: h.Keys}
+}
+
+func (s *String) CreateIterator() Iterator {
+	return &stringIterator{value: s.Value}
+}
+
+func (r *Range) CreateIterator() Iterator {
+	return &rangeIterator{current: r.Start, end: r.End, step: r.Step}
+}
+
+func (it *arrayIterator) Next() (Object, Object, bool) {
+	if it.index >= len(it.elements) {
+		return nil, nil, false
+	}
+	key := &Integer{Value: int64(it.index)}
+	value := it.elements[it.index]
+	it.index += 1
+	return key, value, true
+}
+
+func (it *hashIterator) Next() (Object, Object, bool) {
+	for it.index < len(it.keys) {
+		pair, ok := it.hash.Pairs[it.keys[it.index]]
+		it.index += 1
+		if ok {
+			return pair.Key, pair.Value, true
+		}
+	}
+	return nil, nil, false
+}
+
+func (it *stringIterator) Next() (Object, Object, bool) {
+	if it.offset >= len(it.value) {
+		return nil, nil, false
+	}
+	_, size := utf8.DecodeRuneInString(it.value[it.offset:])
+	key := &Integer{Value: int64(it.index)}
+	value := &String{Value: it.value[it.offset : it.offset+size]}
+	it.offset += size
+	it.index += 1
+	return key, value, true
+}
+
+func (it *rangeIterator) Next() (Object, Object, bool) {
+	if it.step > 0 && it.current >= it.end || it.step < 0 && it.current <= it.end || it.step == 0 {
+		return nil, nil, false
+	}
+	key := &Integer{Value: int64(it.index)}
+	value := &Integer{Value: it.current}
+	if it.step > 0 && it.current > math.MaxInt64-it.step || it.step < 0 && it.current < math.MinInt64-it.step {
+		it.current = it.end
+	} else {
+		it.current += it.step
+	}
+	it.index += 1
+	return key, value, true
+}
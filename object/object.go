@@ -8,9 +8,12 @@ import (
 	"bytes"
 	"fmt"
 	"hash/fnv"
+	"math"
+	"strconv"
 	"strings"
 
 	"github.com/klaytonkowalski/example-interpreter/ast"
+	"github.com/klaytonkowalski/example-interpreter/token"
 )
 
 ////////////////////////////////////////////////////////////////////////////////
@@ -19,15 +22,19 @@ import (
 
 const (
 	ObjectInteger        = "Integer"
+	ObjectFloat          = "Float"
 	ObjectBoolean        = "Boolean"
 	ObjectNull           = "Null"
 	ObjectReturn         = "Return"
+	ObjectBreak          = "Break"
+	ObjectContinue       = "Continue"
 	ObjectError          = "Error"
 	ObjectFunction       = "Function"
 	ObjectString         = "String"
 	ObjectNativeFunction = "Native Function"
 	ObjectArray          = "Array"
 	ObjectHash           = "Hash"
+	ObjectRange          = "Range"
 )
 
 ////////////////////////////////////////////////////////////////////////////////
@@ -51,6 +58,10 @@ type Integer struct {
 	Value int64
 }
 
+type Float struct {
+	Value float64
+}
+
 type Boolean struct {
 	Value bool
 }
@@ -61,12 +72,23 @@ type Return struct {
 	Value Object
 }
 
+type Break struct {
+	Position token.Position
+}
+
+type Continue struct {
+	Position token.Position
+}
+
 type Error struct {
-	Message string
+	Message  string
+	Position token.Position
 }
 
 type Function struct {
 	Parameters  []*ast.Identifier
+	Defaults    []ast.Expression
+	Rest        *ast.Identifier
 	Body        *ast.BlockStatement
 	Environment *Environment
 }
@@ -95,6 +117,13 @@ type HashPair struct {
 
 type Hash struct {
 	Pairs map[HashKey]HashPair
+	Keys  []HashKey
+}
+
+type Range struct {
+	Start int64
+	End   int64
+	Step  int64
 }
 
 ////////////////////////////////////////////////////////////////////////////////
@@ -113,6 +142,25 @@ func (i *Integer) GetHashKey() HashKey {
 	return HashKey{Type: i.GetType(), Value: uint64(i.Value)}
 }
 
+func (f *Float) GetType() string {
+	return ObjectFloat
+}
+
+func (f *Float) GetDebugString() string {
+	code := strconv.FormatFloat(f.Value, 'g', -1, 64)
+	if strings.ContainsAny(code, ".eIN") {
+		return code
+	}
+	return code + ".0"
+}
+
+func (f *Float) GetHashKey() HashKey {
+	if f.Value == math.Trunc(f.Value) && math.Abs(f.Value) < math.MaxInt64 {
+		return HashKey{Type: ObjectInteger, Value: uint64(int64(f.Value))}
+	}
+	return HashKey{Type: f.GetType(), Value: math.Float64bits(f.Value)}
+}
+
 func (b *Boolean) GetType() string {
 	return ObjectBoolean
 }
@@ -147,11 +195,30 @@ func (r *Return) GetDebugString() string {
 	return r.Value.GetDebugString()
 }
 
+func (b *Break) GetType() string {
+	return ObjectBreak
+}
+
+func (b *Break) GetDebugString() string {
+	return "break"
+}
+
+func (c *Continue) GetType() string {
+	return ObjectContinue
+}
+
+func (c *Continue) GetDebugString() string {
+	return "continue"
+}
+
 func (e *Error) GetType() string {
 	return ObjectError
 }
 
 func (e *Error) GetDebugString() string {
+	if e.Position.IsValid() {
+		return "Error at " + e.Position.String() + ": " + e.Message
+	}
 	return "Error: " + e.Message
 }
 
@@ -162,8 +229,15 @@ func (f *Function) GetType() string {
 func (f *Function) GetDebugString() string {
 	var out bytes.Buffer
 	params := []string{}
-	for _, param := range f.Parameters {
-		params = append(params, param.GetDebugString())
+	for i, param := range f.Parameters {
+		if f.Defaults != nil && f.Defaults[i] != nil {
+			params = append(params, param.GetDebugString()+"="+f.Defaults[i].GetDebugString())
+		} else {
+			params = append(params, param.GetDebugString())
+		}
+	}
+	if f.Rest != nil {
+		params = append(params, "..."+f.Rest.GetDebugString())
 	}
 	out.WriteString("fn(")
 	out.WriteString(strings.Join(params, ","))
@@ -218,7 +292,7 @@ func (h *Hash) GetType() string {
 func (h *Hash) GetDebugString() string {
 	var out bytes.Buffer
 	pairs := []string{}
-	for _, pair := range h.Pairs {
+	for _, pair := range h.GetPairs() {
 		pairs = append(pairs, fmt.Sprintf("%s: %s", pair.Key.GetDebugString(), pair.Value.GetDebugString()))
 	}
 	out.WriteString("{")
@@ -227,8 +301,38 @@ func (h *Hash) GetDebugString() string {
 	return out.String()
 }
 
+func (h *Hash) SetPair(key HashKey, pair HashPair) {
+	if _, ok := h.Pairs[key]; !ok {
+		h.Keys = append(h.Keys, key)
+	}
+	h.Pairs[key] = pair
+}
+
+func (h *Hash) GetPairs() []HashPair {
+	pairs := make([]HashPair, 0, len(h.Keys))
+	for _, key := range h.Keys {
+		pairs = append(pairs, h.Pairs[key])
+	}
+	return pairs
+}
+
+func (r *Range) GetType() string {
+	return ObjectRange
+}
+
+func (r *Range) GetDebugString() string {
+	if r.Step == 1 {
+		return fmt.Sprintf("range(%d, %d)", r.Start, r.End)
+	}
+	return fmt.Sprintf("range(%d, %d, %d)", r.Start, r.End, r.Step)
+}
+
 ////////////////////////////////////////////////////////////////////////////////
 // FUNCTIONS
 ////////////////////////////////////////////////////////////////////////////////
 
+func CreateHash() *Hash {
+	return &Hash{Pairs: make(map[HashKey]HashPair)}
+}
+
 type NativeFn func(args ...Object) Object
@@ -0,0 +1,65 @@
+package parser
+
+////////////////////////////////////////////////////////////////////////////////
+// DEPENDENCIES
+////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"strings"
+
+	"github.com/klaytonkowalski/example-interpreter/token"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// VARIABLES
+////////////////////////////////////////////////////////////////////////////////
+
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// STRUCTURES
+////////////////////////////////////////////////////////////////////////////////
+
+type ParseError struct {
+	Position token.Position `json:"position"`
+	Expected []string       `json:"expected,omitempty"`
+	Actual   token.Token    `json:"actual"`
+	Message  string         `json:"message"`
+	Severity string         `json:"severity"`
+}
+
+type ErrorList []*ParseError
+
+////////////////////////////////////////////////////////////////////////////////
+// METHODS
+////////////////////////////////////////////////////////////////////////////////
+
+func (pe *ParseError) Error() string {
+	return pe.Position.String() + ": " + pe.Message
+}
+
+func (el ErrorList) Error() string {
+	messages := []string{}
+	for _, err := range el {
+		messages = append(messages, err.Error())
+	}
+	return strings.Join(messages, "\n")
+}
+
+func (el ErrorList) As(target interface{}) bool {
+	if len(el) == 0 {
+		return false
+	}
+	switch target := target.(type) {
+	case **ParseError:
+		*target = el[0]
+		return true
+	case *ErrorList:
+		*target = el
+		return true
+	}
+	return false
+}
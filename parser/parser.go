@@ -5,8 +5,10 @@ package parser
 ////////////////////////////////////////////////////////////////////////////////
 
 import (
+	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/klaytonkowalski/example-interpreter/ast"
 	"github.com/klaytonkowalski/example-interpreter/lexer"
@@ -20,26 +22,65 @@ import (
 const (
 	_ int = iota
 	Lowest
+	Assign
+	Coalesce
+	LogicalOr
+	LogicalAnd
+	BitwiseOr
+	BitwiseXor
+	BitwiseAnd
 	Equals
 	LessOrGreaterThan
+	Shift
 	Sum
 	Product
 	Prefix
+	Exponent
 	Call
 	Index
 )
 
 var precedences = map[string]int{
-	token.IsEqualTo:       Equals,
-	token.IsNotEqualTo:    Equals,
-	token.LessThan:        LessOrGreaterThan,
-	token.GreaterThan:     LessOrGreaterThan,
-	token.Plus:            Sum,
-	token.Minus:           Sum,
-	token.ForwardSlash:    Product,
-	token.Asterisk:        Product,
-	token.LeftParenthesis: Call,
-	token.LeftBracket:     Index,
+	token.Equals:               Assign,
+	token.PlusEquals:           Assign,
+	token.MinusEquals:          Assign,
+	token.AsteriskEquals:       Assign,
+	token.ForwardSlashEquals:   Assign,
+	token.DoubleQuestion:       Coalesce,
+	token.DoublePipe:           LogicalOr,
+	token.DoubleAmpersand:      LogicalAnd,
+	token.Pipe:                 BitwiseOr,
+	token.Caret:                BitwiseXor,
+	token.Ampersand:            BitwiseAnd,
+	token.IsEqualTo:            Equals,
+	token.IsNotEqualTo:         Equals,
+	token.LessThan:             LessOrGreaterThan,
+	token.GreaterThan:          LessOrGreaterThan,
+	token.LessThanOrEqualTo:    LessOrGreaterThan,
+	token.GreaterThanOrEqualTo: LessOrGreaterThan,
+	token.DoubleLessThan:       Shift,
+	token.DoubleGreaterThan:    Shift,
+	token.Plus:                 Sum,
+	token.Minus:                Sum,
+	token.ForwardSlash:         Product,
+	token.Asterisk:             Product,
+	token.Percent:              Product,
+	token.DoubleAsterisk:       Exponent,
+	token.LeftParenthesis:      Call,
+	token.LeftBracket:          Index,
+	token.QuestionDot:          Index,
+}
+
+var synchronizingCategories = map[string]bool{
+	token.Let:      true,
+	token.Const:    true,
+	token.Return:   true,
+	token.While:    true,
+	token.For:      true,
+	token.Break:    true,
+	token.Case:     true,
+	token.Default:  true,
+	token.Continue: true,
 }
 
 ////////////////////////////////////////////////////////////////////////////////
@@ -50,7 +91,13 @@ type Parser struct {
 	lxr             *lexer.Lexer
 	tok             token.Token
 	nextTok         token.Token
-	Errors          []string
+	doc             string
+	nextDoc         string
+	lexerErrors     int
+	panicking       bool
+	depth           int
+	braces          []bool
+	Errors          []*ParseError
 	prefixFunctions map[string]parsePrefixFunc
 	infixFunctions  map[string]parseInfixFunc
 }
@@ -59,37 +106,85 @@ type Parser struct {
 // METHODS
 ////////////////////////////////////////////////////////////////////////////////
 
-func (p *Parser) ParseProgram() *ast.Program {
+func (p *Parser) ParseProgram() (*ast.Program, error) {
 	program := &ast.Program{}
 	program.Statements = []ast.Statement{}
 	for p.tok.Category != token.End {
 		statement := p.parseStatement()
-		if statement != nil {
+		if p.panicking {
+			p.synchronize(0)
+		} else if statement != nil {
 			program.Statements = append(program.Statements, statement)
 		}
 		p.GetNextToken()
 	}
-	return program
+	if len(p.Errors) > 0 {
+		return program, ErrorList(p.Errors)
+	}
+	return program, nil
 }
 
 func (p *Parser) parseStatement() ast.Statement {
 	switch p.tok.Category {
 	case token.Let:
 		return p.parseLetStatement()
+	case token.Const:
+		return p.parseConstStatement()
 	case token.Return:
 		return p.parseReturnStatement()
+	case token.While:
+		return p.parseWhileStatement()
+	case token.For:
+		return p.parseForStatement()
+	case token.Break:
+		return p.parseBreakStatement()
+	case token.Continue:
+		return p.parseContinueStatement()
 	default:
 		return p.parseExpressionStatement()
 	}
 }
 
 func (p *Parser) parseLetStatement() *ast.LetStatement {
-	statement := &ast.LetStatement{LetToken: p.tok}
-	if !p.assertNextToken(token.Identifier) {
+	statement := &ast.LetStatement{LetToken: p.tok, Doc: p.doc}
+	if p.nextTok.Category == token.LeftBracket || p.nextTok.Category == token.LeftBrace {
+		p.GetNextToken()
+		if statement.Pattern = p.parsePattern(); statement.Pattern == nil {
+			return nil
+		}
+	} else {
+		if !p.assertNextToken(token.Identifier) {
+			return nil
+		}
+		p.GetNextToken()
+		statement.Identifier = &ast.Identifier{Token: p.tok, Value: p.tok.Code}
+	}
+	if !p.assertNextToken(token.Equals) {
 		return nil
 	}
 	p.GetNextToken()
-	statement.Identifier = &ast.Identifier{Token: p.tok, Value: p.tok.Code}
+	p.GetNextToken()
+	statement.Expression = p.parseExpression(Lowest)
+	for p.nextTok.Category == token.Semicolon {
+		p.GetNextToken()
+	}
+	return statement
+}
+
+func (p *Parser) parseConstStatement() *ast.ConstStatement {
+	statement := &ast.ConstStatement{ConstToken: p.tok, Doc: p.doc}
+	if p.nextTok.Category == token.LeftBracket || p.nextTok.Category == token.LeftBrace {
+		p.GetNextToken()
+		if statement.Pattern = p.parsePattern(); statement.Pattern == nil {
+			return nil
+		}
+	} else {
+		if !p.assertNextToken(token.Identifier) {
+			return nil
+		}
+		p.GetNextToken()
+		statement.Identifier = &ast.Identifier{Token: p.tok, Value: p.tok.Code}
+	}
 	if !p.assertNextToken(token.Equals) {
 		return nil
 	}
@@ -102,6 +197,106 @@ func (p *Parser) parseLetStatement() *ast.LetStatement {
 	return statement
 }
 
+func (p *Parser) parsePattern() ast.Pattern {
+	switch p.tok.Category {
+	case token.Identifier:
+		if p.tok.Code == "_" {
+			return &ast.Wildcard{Token: p.tok}
+		}
+		return &ast.Identifier{Token: p.tok, Value: p.tok.Code}
+	case token.LeftBracket:
+		return p.parseArrayPattern()
+	case token.LeftBrace:
+		return p.parseHashPattern()
+	case token.Integer, token.Float, token.String, token.True, token.False, token.Null:
+		return p.prefixFunctions[p.tok.Category]()
+	case token.Minus:
+		literal := &ast.PrefixExpression{PrefixToken: p.tok, Operator: p.tok.Code}
+		if p.nextTok.Category != token.Integer && p.nextTok.Category != token.Float {
+			p.appendCategoryError(token.Integer)
+			return nil
+		}
+		p.GetNextToken()
+		if literal.RHSExpression = p.prefixFunctions[p.tok.Category](); literal.RHSExpression == nil {
+			return nil
+		}
+		return literal
+	}
+	expected := []string{token.Identifier, token.LeftBracket, token.LeftBrace}
+	message := fmt.Sprintf("expected pattern, got %s instead", p.tok.Category)
+	p.appendError(p.tok.Position, expected, p.tok, message)
+	return nil
+}
+
+func (p *Parser) parseArrayPattern() ast.Pattern {
+	pattern := &ast.ArrayPattern{Token: p.tok, Elements: []ast.Pattern{}}
+	for p.nextTok.Category != token.RightBracket {
+		p.GetNextToken()
+		if p.tok.Category == token.Ellipsis {
+			if !p.assertNextToken(token.Identifier) {
+				return nil
+			}
+			p.GetNextToken()
+			pattern.Rest = &ast.Identifier{Token: p.tok, Value: p.tok.Code}
+			break
+		}
+		element := p.parsePattern()
+		if element == nil {
+			return nil
+		}
+		pattern.Elements = append(pattern.Elements, element)
+		if p.nextTok.Category != token.Comma {
+			break
+		}
+		p.GetNextToken()
+	}
+	if !p.assertNextToken(token.RightBracket) {
+		return nil
+	}
+	p.GetNextToken()
+	return pattern
+}
+
+func (p *Parser) parseHashPattern() ast.Pattern {
+	pattern := &ast.HashPattern{Token: p.tok, Pairs: []*ast.HashPatternPair{}}
+	for p.nextTok.Category != token.RightBrace {
+		p.GetNextToken()
+		pair := &ast.HashPatternPair{}
+		switch p.tok.Category {
+		case token.Identifier:
+			pair.Key = &ast.String{Token: p.tok, Value: p.tok.Code}
+			pair.Value = &ast.Identifier{Token: p.tok, Value: p.tok.Code}
+		case token.String:
+			pair.Key = &ast.String{Token: p.tok, Value: p.tok.Code}
+		default:
+			expected := []string{token.Identifier, token.String}
+			message := fmt.Sprintf("expected hash pattern key, got %s instead", p.tok.Category)
+			p.appendError(p.tok.Position, expected, p.tok, message)
+			return nil
+		}
+		if p.nextTok.Category == token.Colon || pair.Value == nil {
+			if !p.assertNextToken(token.Colon) {
+				return nil
+			}
+			p.GetNextToken()
+			p.GetNextToken()
+			if pair.Value = p.parsePattern(); pair.Value == nil {
+				return nil
+			}
+		}
+		pattern.Pairs = append(pattern.Pairs, pair)
+		if p.nextTok.Category != token.Comma {
+			break
+		}
+		p.GetNextToken()
+	}
+	if !p.assertNextToken(token.RightBrace) {
+		return nil
+	}
+	p.GetNextToken()
+	return pattern
+}
+
 func (p *Parser) parseReturnStatement() *ast.ReturnStatement {
 	statement := &ast.ReturnStatement{Token: p.tok}
 	p.GetNextToken()
@@ -112,6 +307,80 @@ func (p *Parser) parseReturnStatement() *ast.ReturnStatement {
 	return statement
 }
 
+func (p *Parser) parseWhileStatement() *ast.WhileStatement {
+	statement := &ast.WhileStatement{Token: p.tok}
+	if !p.assertNextToken(token.LeftParenthesis) {
+		return nil
+	}
+	p.GetNextToken()
+	p.GetNextToken()
+	statement.Condition = p.parseExpression(Lowest)
+	if !p.assertNextToken(token.RightParenthesis) {
+		return nil
+	}
+	p.GetNextToken()
+	if !p.assertNextToken(token.LeftBrace) {
+		return nil
+	}
+	p.GetNextToken()
+	statement.Body = p.parseBlockStatement()
+	return statement
+}
+
+func (p *Parser) parseForStatement() *ast.ForStatement {
+	statement := &ast.ForStatement{Token: p.tok}
+	if !p.assertNextToken(token.LeftParenthesis) {
+		return nil
+	}
+	p.GetNextToken()
+	if !p.assertNextToken(token.Identifier) {
+		return nil
+	}
+	p.GetNextToken()
+	statement.Value = &ast.Identifier{Token: p.tok, Value: p.tok.Code}
+	if p.nextTok.Category == token.Comma {
+		p.GetNextToken()
+		if !p.assertNextToken(token.Identifier) {
+			return nil
+		}
+		p.GetNextToken()
+		statement.Key = statement.Value
+		statement.Value = &ast.Identifier{Token: p.tok, Value: p.tok.Code}
+	}
+	if !p.assertNextToken(token.In) {
+		return nil
+	}
+	p.GetNextToken()
+	p.GetNextToken()
+	statement.Iterable = p.parseExpression(Lowest)
+	if !p.assertNextToken(token.RightParenthesis) {
+		return nil
+	}
+	p.GetNextToken()
+	if !p.assertNextToken(token.LeftBrace) {
+		return nil
+	}
+	p.GetNextToken()
+	statement.Body = p.parseBlockStatement()
+	return statement
+}
+
+func (p *Parser) parseBreakStatement() *ast.BreakStatement {
+	statement := &ast.BreakStatement{Token: p.tok}
+	for p.nextTok.Category == token.Semicolon {
+		p.GetNextToken()
+	}
+	return statement
+}
+
+func (p *Parser) parseContinueStatement() *ast.ContinueStatement {
+	statement := &ast.ContinueStatement{Token: p.tok}
+	for p.nextTok.Category == token.Semicolon {
+		p.GetNextToken()
+	}
+	return statement
+}
+
 func (p *Parser) parseExpressionStatement() *ast.ExpressionStatement {
 	statement := &ast.ExpressionStatement{Token: p.tok}
 	statement.Expression = p.parseExpression(Lowest)
@@ -124,10 +393,21 @@ func (p *Parser) parseExpressionStatement() *ast.ExpressionStatement {
 func (p *Parser) parseBlockStatement() *ast.BlockStatement {
 	block := &ast.BlockStatement{Token: p.tok}
 	block.Statements = []ast.Statement{}
+	p.openBlock()
+	depth := p.depth
 	p.GetNextToken()
-	for p.tok.Category != token.RightBrace && p.tok.Category != token.End {
+	for p.depth >= depth {
+		if p.tok.Category == token.End {
+			p.appendError(block.Token.Position, []string{token.RightBrace}, p.tok, "unterminated block")
+			break
+		}
 		statement := p.parseStatement()
-		if statement != nil {
+		if p.panicking {
+			p.synchronize(depth)
+			if p.depth < depth {
+				break
+			}
+		} else if statement != nil {
 			block.Statements = append(block.Statements, statement)
 		}
 		p.GetNextToken()
@@ -135,6 +415,39 @@ func (p *Parser) parseBlockStatement() *ast.BlockStatement {
 	return block
 }
 
+func (p *Parser) synchronize(depth int) {
+	p.panicking = false
+	for p.depth >= depth && p.nextTok.Category != token.End {
+		if p.depth == depth {
+			if synchronizingCategories[p.nextTok.Category] {
+				p.closeBraces()
+				return
+			}
+			if !p.isInsideBraces() && (p.tok.Category == token.Semicolon || p.nextTok.Category == token.RightBrace) {
+				return
+			}
+		}
+		p.GetNextToken()
+	}
+}
+
+func (p *Parser) openBlock() {
+	if len(p.braces) > 0 {
+		p.braces[len(p.braces)-1] = true
+		p.depth += 1
+	}
+}
+
+func (p *Parser) isInsideBraces() bool {
+	return len(p.braces) > 0 && !p.braces[len(p.braces)-1]
+}
+
+func (p *Parser) closeBraces() {
+	for p.isInsideBraces() {
+		p.braces = p.braces[:len(p.braces)-1]
+	}
+}
+
 func (p *Parser) parseExpression(precedence int) ast.Expression {
 	prefix := p.prefixFunctions[p.tok.Category]
 	if prefix == nil {
@@ -174,27 +487,68 @@ func (p *Parser) parseInfix(lhsExpression ast.Expression) ast.Expression {
 		LHSExpression: lhsExpression,
 	}
 	precedence := p.getPrecedence()
+	if expression.InfixToken.Category == token.DoubleAsterisk {
+		precedence -= 1
+	}
 	p.GetNextToken()
 	expression.RHSExpression = p.parseExpression(precedence)
 	return expression
 }
 
+func (p *Parser) parseAssignment(target ast.Expression) ast.Expression {
+	expression := &ast.AssignmentExpression{
+		Token:    p.tok,
+		Target:   target,
+		Operator: p.tok.Code,
+	}
+	switch target := target.(type) {
+	case *ast.Identifier:
+	case *ast.Index:
+		if target.Optional {
+			p.appendError(target.GetPosition(), nil, p.tok, fmt.Sprintf("cannot assign to %s", target.GetDebugString()))
+			return nil
+		}
+	default:
+		if target != nil {
+			p.appendError(target.GetPosition(), nil, p.tok, fmt.Sprintf("cannot assign to %s", target.GetDebugString()))
+		}
+		return nil
+	}
+	p.GetNextToken()
+	expression.Value = p.parseExpression(Assign - 1)
+	return expression
+}
+
 func (p *Parser) parseInteger() ast.Expression {
 	integer := &ast.Integer{Token: p.tok}
 	value, err := strconv.ParseInt(p.tok.Code, 0, 64)
 	if err != nil {
-		message := fmt.Sprintf("could not parse %q as integer", p.tok.Code)
-		p.Errors = append(p.Errors, message)
+		p.appendNumberError("integer", err)
 		return nil
 	}
 	integer.Value = value
 	return integer
 }
 
+func (p *Parser) parseFloat() ast.Expression {
+	float := &ast.Float{Token: p.tok}
+	value, err := strconv.ParseFloat(p.tok.Code, 64)
+	if err != nil {
+		p.appendNumberError("float", err)
+		return nil
+	}
+	float.Value = value
+	return float
+}
+
 func (p *Parser) parseBoolean() ast.Expression {
 	return &ast.Boolean{Token: p.tok, Value: p.tok.Category == token.True}
 }
 
+func (p *Parser) parseNull() ast.Expression {
+	return &ast.Null{Token: p.tok}
+}
+
 func (p *Parser) parseGroup() ast.Expression {
 	p.GetNextToken()
 	exp := p.parseExpression(Lowest)
@@ -224,6 +578,13 @@ func (p *Parser) parseIf() ast.Expression {
 	exp.Then = p.parseBlockStatement()
 	if p.nextTok.Category == token.Else {
 		p.GetNextToken()
+		if p.nextTok.Category == token.If {
+			p.GetNextToken()
+			if exp.ElseIf, _ = p.parseIf().(*ast.IfExpression); exp.ElseIf == nil {
+				return nil
+			}
+			return exp
+		}
 		if !p.assertNextToken(token.LeftBrace) {
 			return nil
 		}
@@ -233,13 +594,152 @@ func (p *Parser) parseIf() ast.Expression {
 	return exp
 }
 
+func (p *Parser) parseSwitch() ast.Expression {
+	exp := &ast.SwitchExpression{Token: p.tok, Cases: []*ast.SwitchCase{}}
+	if !p.assertNextToken(token.LeftParenthesis) {
+		return nil
+	}
+	p.GetNextToken()
+	p.GetNextToken()
+	exp.Subject = p.parseExpression(Lowest)
+	if !p.assertNextToken(token.RightParenthesis) {
+		return nil
+	}
+	p.GetNextToken()
+	if !p.assertNextToken(token.LeftBrace) {
+		return nil
+	}
+	p.GetNextToken()
+	brace := p.tok
+	p.openBlock()
+	depth := p.depth
+	p.GetNextToken()
+	var current *ast.SwitchCase
+	for p.depth >= depth {
+		switch p.tok.Category {
+		case token.End:
+			p.appendError(brace.Position, []string{token.RightBrace}, p.tok, "unterminated switch")
+			return nil
+		case token.Case, token.Default:
+			if current = p.parseSwitchCase(exp); current == nil {
+				return nil
+			}
+			exp.Cases = append(exp.Cases, current)
+		default:
+			if current == nil {
+				expected := []string{token.Case, token.Default}
+				message := fmt.Sprintf("expected case or default, got %s instead", p.tok.Category)
+				p.appendError(p.tok.Position, expected, p.tok, message)
+				return nil
+			}
+			statement := p.parseStatement()
+			if p.panicking {
+				p.synchronize(depth)
+				if p.depth < depth {
+					return exp
+				}
+			} else if statement != nil {
+				current.Body.Statements = append(current.Body.Statements, statement)
+			}
+		}
+		p.GetNextToken()
+	}
+	return exp
+}
+
+func (p *Parser) parseSwitchCase(exp *ast.SwitchExpression) *ast.SwitchCase {
+	switchCase := &ast.SwitchCase{Token: p.tok, Default: p.tok.Category == token.Default}
+	if switchCase.Default {
+		for _, c := range exp.Cases {
+			if c.Default {
+				p.appendError(p.tok.Position, nil, p.tok, "multiple default cases in switch")
+				return nil
+			}
+		}
+	} else {
+		p.GetNextToken()
+		switchCase.Values = append(switchCase.Values, p.parseExpression(Lowest))
+		for p.nextTok.Category == token.Comma {
+			p.GetNextToken()
+			p.GetNextToken()
+			switchCase.Values = append(switchCase.Values, p.parseExpression(Lowest))
+		}
+	}
+	if !p.assertNextToken(token.Colon) {
+		return nil
+	}
+	p.GetNextToken()
+	switchCase.Body = &ast.BlockStatement{Token: p.tok, Statements: []ast.Statement{}}
+	return switchCase
+}
+
+func (p *Parser) parseMatch() ast.Expression {
+	exp := &ast.MatchExpression{Token: p.tok, Arms: []*ast.MatchArm{}}
+	if !p.assertNextToken(token.LeftParenthesis) {
+		return nil
+	}
+	p.GetNextToken()
+	p.GetNextToken()
+	exp.Subject = p.parseExpression(Lowest)
+	if !p.assertNextToken(token.RightParenthesis) {
+		return nil
+	}
+	p.GetNextToken()
+	if !p.assertNextToken(token.LeftBrace) {
+		return nil
+	}
+	p.GetNextToken()
+	for p.nextTok.Category != token.RightBrace {
+		p.GetNextToken()
+		arm := p.parseMatchArm()
+		if arm == nil {
+			return nil
+		}
+		exp.Arms = append(exp.Arms, arm)
+		if p.nextTok.Category != token.Comma {
+			break
+		}
+		p.GetNextToken()
+	}
+	if !p.assertNextToken(token.RightBrace) {
+		return nil
+	}
+	p.GetNextToken()
+	return exp
+}
+
+func (p *Parser) parseMatchArm() *ast.MatchArm {
+	arm := &ast.MatchArm{}
+	if arm.Pattern = p.parsePattern(); arm.Pattern == nil {
+		return nil
+	}
+	if p.nextTok.Category == token.If {
+		p.GetNextToken()
+		p.GetNextToken()
+		arm.Guard = p.parseExpression(Lowest)
+	}
+	if !p.assertNextToken(token.FatArrow) {
+		return nil
+	}
+	p.GetNextToken()
+	p.GetNextToken()
+	if p.tok.Category == token.LeftBrace {
+		arm.Body = p.parseBlockStatement()
+	} else {
+		arm.Body = &ast.ExpressionStatement{Token: p.tok, Expression: p.parseExpression(Lowest)}
+	}
+	return arm
+}
+
 func (p *Parser) parseFunction() ast.Expression {
 	fn := &ast.Function{Token: p.tok}
 	if !p.assertNextToken(token.LeftParenthesis) {
 		return nil
 	}
 	p.GetNextToken()
-	fn.Parameters = p.parseFunctionParameters()
+	if !p.parseFunctionParameters(fn) {
+		return nil
+	}
 	if !p.assertNextToken(token.LeftBrace) {
 		return nil
 	}
@@ -248,26 +748,50 @@ func (p *Parser) parseFunction() ast.Expression {
 	return fn
 }
 
-func (p *Parser) parseFunctionParameters() []*ast.Identifier {
-	ids := []*ast.Identifier{}
-	if p.nextTok.Category == token.RightParenthesis {
-		p.GetNextToken()
-		return ids
-	}
-	p.GetNextToken()
-	id := &ast.Identifier{Token: p.tok, Value: p.tok.Code}
-	ids = append(ids, id)
-	for p.nextTok.Category == token.Comma {
+func (p *Parser) parseFunctionParameters(fn *ast.Function) bool {
+	fn.Parameters = []*ast.Identifier{}
+	for p.nextTok.Category != token.RightParenthesis {
+		if p.nextTok.Category == token.Ellipsis {
+			p.GetNextToken()
+			if !p.assertNextToken(token.Identifier) {
+				return false
+			}
+			p.GetNextToken()
+			fn.Rest = &ast.Identifier{Token: p.tok, Value: p.tok.Code}
+			break
+		}
+		if !p.assertNextToken(token.Identifier) {
+			return false
+		}
 		p.GetNextToken()
+		param := &ast.Identifier{Token: p.tok, Value: p.tok.Code}
+		var value ast.Expression
+		if p.nextTok.Category == token.Equals {
+			p.GetNextToken()
+			p.GetNextToken()
+			value = p.parseExpression(Lowest)
+			if fn.Defaults == nil {
+				fn.Defaults = make([]ast.Expression, len(fn.Parameters))
+			}
+		} else if fn.Defaults != nil {
+			message := fmt.Sprintf("parameter %s without a default value follows a parameter with one", param.Value)
+			p.appendError(param.Token.Position, nil, param.Token, message)
+			return false
+		}
+		fn.Parameters = append(fn.Parameters, param)
+		if fn.Defaults != nil {
+			fn.Defaults = append(fn.Defaults, value)
+		}
+		if p.nextTok.Category != token.Comma {
+			break
+		}
 		p.GetNextToken()
-		id := &ast.Identifier{Token: p.tok, Value: p.tok.Code}
-		ids = append(ids, id)
 	}
 	if !p.assertNextToken(token.RightParenthesis) {
-		return nil
+		return false
 	}
 	p.GetNextToken()
-	return ids
+	return true
 }
 
 func (p *Parser) parseCall(fn ast.Expression) ast.Expression {
@@ -280,6 +804,21 @@ func (p *Parser) parseString() ast.Expression {
 	return &ast.String{Token: p.tok, Value: p.tok.Code}
 }
 
+func (p *Parser) parseInterpolatedString() ast.Expression {
+	str := &ast.InterpolatedString{Token: p.tok}
+	str.Parts = append(str.Parts, &ast.String{Token: p.tok, Value: p.tok.Code})
+	for p.tok.Category != token.StringEnd {
+		p.GetNextToken()
+		str.Parts = append(str.Parts, p.parseExpression(Lowest))
+		if p.nextTok.Category != token.StringMiddle && !p.assertNextToken(token.StringEnd) {
+			return nil
+		}
+		p.GetNextToken()
+		str.Parts = append(str.Parts, &ast.String{Token: p.tok, Value: p.tok.Code})
+	}
+	return str
+}
+
 func (p *Parser) parseArray() ast.Expression {
 	array := &ast.Array{Token: p.tok}
 	array.Elements = p.parseExpressionList(token.RightBracket)
@@ -293,11 +832,11 @@ func (p *Parser) parseExpressionList(closingCategory string) []ast.Expression {
 		return list
 	}
 	p.GetNextToken()
-	list = append(list, p.parseExpression(Lowest))
+	list = append(list, p.parseListElement())
 	for p.nextTok.Category == token.Comma {
 		p.GetNextToken()
 		p.GetNextToken()
-		list = append(list, p.parseExpression(Lowest))
+		list = append(list, p.parseListElement())
 	}
 	if !p.assertNextToken(closingCategory) {
 		return nil
@@ -306,6 +845,16 @@ func (p *Parser) parseExpressionList(closingCategory string) []ast.Expression {
 	return list
 }
 
+func (p *Parser) parseListElement() ast.Expression {
+	if p.tok.Category != token.Ellipsis {
+		return p.parseExpression(Lowest)
+	}
+	spread := &ast.Spread{Token: p.tok}
+	p.GetNextToken()
+	spread.Expression = p.parseExpression(Lowest)
+	return spread
+}
+
 func (p *Parser) parseIndex(identifierExp ast.Expression) ast.Expression {
 	exp := &ast.Index{Token: p.tok, IdentifierExpression: identifierExp}
 	p.GetNextToken()
@@ -317,9 +866,32 @@ func (p *Parser) parseIndex(identifierExp ast.Expression) ast.Expression {
 	return exp
 }
 
+func (p *Parser) parseOptionalIndex(identifierExp ast.Expression) ast.Expression {
+	exp := &ast.Index{Token: p.tok, IdentifierExpression: identifierExp, Optional: true}
+	switch p.nextTok.Category {
+	case token.Identifier:
+		p.GetNextToken()
+		exp.IndexExpression = &ast.String{Token: p.tok, Value: p.tok.Code}
+		return exp
+	case token.LeftBracket:
+		p.GetNextToken()
+		p.GetNextToken()
+		exp.IndexExpression = p.parseExpression(Lowest)
+		if !p.assertNextToken(token.RightBracket) {
+			return nil
+		}
+		p.GetNextToken()
+		return exp
+	}
+	expected := []string{token.Identifier, token.LeftBracket}
+	message := fmt.Sprintf("expected key or index after ?., got %s instead", p.nextTok.Category)
+	p.appendError(p.nextTok.Position, expected, p.nextTok, message)
+	return nil
+}
+
 func (p *Parser) parseHash() ast.Expression {
 	hash := &ast.Hash{Token: p.tok}
-	hash.Pairs = make(map[ast.Expression]ast.Expression)
+	hash.Pairs = []*ast.HashPair{}
 	for p.nextTok.Category != token.RightBrace {
 		p.GetNextToken()
 		key := p.parseExpression(Lowest)
@@ -329,7 +901,7 @@ func (p *Parser) parseHash() ast.Expression {
 		p.GetNextToken()
 		p.GetNextToken()
 		value := p.parseExpression(Lowest)
-		hash.Pairs[key] = value
+		hash.Pairs = append(hash.Pairs, &ast.HashPair{Key: key, Value: value})
 		if p.nextTok.Category != token.RightBrace {
 			if !p.assertNextToken(token.Comma) {
 				return nil
@@ -346,7 +918,46 @@ func (p *Parser) parseHash() ast.Expression {
 
 func (p *Parser) GetNextToken() {
 	p.tok = p.nextTok
-	p.nextTok = p.lxr.GetNextToken()
+	if p.tok.Category == token.LeftBrace {
+		p.braces = append(p.braces, false)
+	} else if p.tok.Category == token.RightBrace && len(p.braces) > 0 {
+		if p.braces[len(p.braces)-1] {
+			p.depth -= 1
+		}
+		p.braces = p.braces[:len(p.braces)-1]
+	}
+	p.doc = p.nextDoc
+	p.nextDoc = ""
+	for {
+		p.nextTok = p.lxr.GetNextToken()
+		p.appendLexerErrors()
+		if p.nextTok.Category == token.DocComment {
+			p.appendDocComment(p.nextTok.Code)
+		} else if p.nextTok.Category != token.Comment {
+			break
+		}
+	}
+}
+
+func (p *Parser) appendDocComment(code string) {
+	line := strings.TrimPrefix(strings.TrimPrefix(code, "///"), " ")
+	if p.nextDoc != "" {
+		p.nextDoc += "\n"
+	}
+	p.nextDoc += line
+}
+
+func (p *Parser) appendLexerErrors() {
+	for p.lexerErrors < len(p.lxr.Errors) {
+		err := p.lxr.Errors[p.lexerErrors]
+		p.Errors = append(p.Errors, &ParseError{
+			Position: err.Position,
+			Actual:   p.nextTok,
+			Message:  err.Message,
+			Severity: SeverityError,
+		})
+		p.lexerErrors += 1
+	}
 }
 
 func (p *Parser) assertNextToken(category string) bool {
@@ -358,27 +969,46 @@ func (p *Parser) assertNextToken(category string) bool {
 }
 
 func (p *Parser) getPrecedence() int {
-	if precedence, ok := precedences[p.tok.Category]; ok {
-		return precedence
-	}
-	return Lowest
+	return GetPrecedence(p.tok.Category)
 }
 
 func (p *Parser) getNextPrecedence() int {
-	if precedence, ok := precedences[p.nextTok.Category]; ok {
-		return precedence
-	}
-	return Lowest
+	return GetPrecedence(p.nextTok.Category)
 }
 
 func (p *Parser) appendCategoryError(category string) {
 	message := fmt.Sprintf("expected next token to be %s, got %s instead", category, p.nextTok.Category)
-	p.Errors = append(p.Errors, message)
+	p.appendError(p.nextTok.Position, []string{category}, p.nextTok, message)
+}
+
+func (p *Parser) appendNumberError(kind string, err error) {
+	var message string
+	if errors.Is(err, strconv.ErrRange) {
+		message = fmt.Sprintf("%s literal %s is out of range", kind, p.tok.Code)
+	} else {
+		message = fmt.Sprintf("malformed %s literal %q", kind, p.tok.Code)
+	}
+	p.appendError(p.tok.Position, nil, p.tok, message)
 }
 
 func (p *Parser) appendPrefixError(category string) {
 	message := fmt.Sprintf("no prefix parse function for %s found", category)
-	p.Errors = append(p.Errors, message)
+	p.appendError(p.tok.Position, nil, p.tok, message)
+}
+
+func (p *Parser) appendError(position token.Position, expected []string, actual token.Token, message string) {
+	if p.panicking {
+		return
+	}
+	p.panicking = true
+	err := &ParseError{
+		Position: position,
+		Expected: expected,
+		Actual:   actual,
+		Message:  message,
+		Severity: SeverityError,
+	}
+	p.Errors = append(p.Errors, err)
 }
 
 ////////////////////////////////////////////////////////////////////////////////
@@ -386,20 +1016,26 @@ func (p *Parser) appendPrefixError(category string) {
 ////////////////////////////////////////////////////////////////////////////////
 
 func New(lxr *lexer.Lexer) *Parser {
-	prs := &Parser{lxr: lxr, Errors: []string{}}
+	prs := &Parser{lxr: lxr, Errors: []*ParseError{}}
 	prs.GetNextToken()
 	prs.GetNextToken()
 	prs.prefixFunctions = make(map[string]parsePrefixFunc)
 	prs.prefixFunctions[token.Identifier] = prs.parseIdentifier
 	prs.prefixFunctions[token.Integer] = prs.parseInteger
+	prs.prefixFunctions[token.Float] = prs.parseFloat
 	prs.prefixFunctions[token.Bang] = prs.parsePrefix
 	prs.prefixFunctions[token.Minus] = prs.parsePrefix
+	prs.prefixFunctions[token.Tilde] = prs.parsePrefix
 	prs.prefixFunctions[token.True] = prs.parseBoolean
 	prs.prefixFunctions[token.False] = prs.parseBoolean
+	prs.prefixFunctions[token.Null] = prs.parseNull
 	prs.prefixFunctions[token.LeftParenthesis] = prs.parseGroup
 	prs.prefixFunctions[token.If] = prs.parseIf
+	prs.prefixFunctions[token.Match] = prs.parseMatch
+	prs.prefixFunctions[token.Switch] = prs.parseSwitch
 	prs.prefixFunctions[token.Function] = prs.parseFunction
 	prs.prefixFunctions[token.String] = prs.parseString
+	prs.prefixFunctions[token.StringStart] = prs.parseInterpolatedString
 	prs.prefixFunctions[token.LeftBracket] = prs.parseArray
 	prs.prefixFunctions[token.LeftBrace] = prs.parseHash
 	prs.infixFunctions = make(map[string]parseInfixFunc)
@@ -411,11 +1047,36 @@ func New(lxr *lexer.Lexer) *Parser {
 	prs.infixFunctions[token.IsNotEqualTo] = prs.parseInfix
 	prs.infixFunctions[token.LessThan] = prs.parseInfix
 	prs.infixFunctions[token.GreaterThan] = prs.parseInfix
+	prs.infixFunctions[token.LessThanOrEqualTo] = prs.parseInfix
+	prs.infixFunctions[token.GreaterThanOrEqualTo] = prs.parseInfix
+	prs.infixFunctions[token.Percent] = prs.parseInfix
+	prs.infixFunctions[token.DoubleAsterisk] = prs.parseInfix
+	prs.infixFunctions[token.DoubleAmpersand] = prs.parseInfix
+	prs.infixFunctions[token.DoublePipe] = prs.parseInfix
+	prs.infixFunctions[token.DoubleQuestion] = prs.parseInfix
+	prs.infixFunctions[token.Ampersand] = prs.parseInfix
+	prs.infixFunctions[token.Pipe] = prs.parseInfix
+	prs.infixFunctions[token.Caret] = prs.parseInfix
+	prs.infixFunctions[token.DoubleLessThan] = prs.parseInfix
+	prs.infixFunctions[token.DoubleGreaterThan] = prs.parseInfix
+	prs.infixFunctions[token.Equals] = prs.parseAssignment
+	prs.infixFunctions[token.PlusEquals] = prs.parseAssignment
+	prs.infixFunctions[token.MinusEquals] = prs.parseAssignment
+	prs.infixFunctions[token.AsteriskEquals] = prs.parseAssignment
+	prs.infixFunctions[token.ForwardSlashEquals] = prs.parseAssignment
 	prs.infixFunctions[token.LeftParenthesis] = prs.parseCall
 	prs.infixFunctions[token.LeftBracket] = prs.parseIndex
+	prs.infixFunctions[token.QuestionDot] = prs.parseOptionalIndex
 	return prs
 }
 
+func GetPrecedence(category string) int {
+	if precedence, ok := precedences[category]; ok {
+		return precedence
+	}
+	return Lowest
+}
+
 type parsePrefixFunc func() ast.Expression
 
 type parseInfixFunc func(lhsExpression ast.Expression) ast.Expression
@@ -0,0 +1,41 @@
+package parser
+
+////////////////////////////////////////////////////////////////////////////////
+// DEPENDENCIES
+////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"testing"
+
+	"github.com/klaytonkowalski/example-interpreter/lexer"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// FUNCTIONS
+////////////////////////////////////////////////////////////////////////////////
+
+func TestRecoveryAfterUnclosedHash(t *testing.T) {
+	tests := []struct {
+		script string
+		lines  []int
+	}{
+		{"let b = {\"a\": 1;\nlet c = ;\n", []int{1, 2}},
+		{"let f = fn() {\n  let b = {\"a\": 1;\n  let c = ;\n};\nlet e = ;\n", []int{2, 3, 5}},
+		{"let b = {\"a\": 1 + ; \"c\": 2};\nlet d = ;\n", []int{1, 2}},
+	}
+	for _, test := range tests {
+		_, err := New(lexer.New(test.script)).ParseProgram()
+		errs, ok := err.(ErrorList)
+		if !ok {
+			t.Fatalf("%q: expected ErrorList, got %v", test.script, err)
+		}
+		if len(errs) != len(test.lines) {
+			t.Fatalf("%q: expected %d errors, got %d:\n%v", test.script, len(test.lines), len(errs), err)
+		}
+		for i, line := range test.lines {
+			if errs[i].Position.Line != line {
+				t.Errorf("%q: error %d on line %d, expected line %d", test.script, i, errs[i].Position.Line, line)
+			}
+		}
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"io"
 
+	"github.com/klaytonkowalski/example-interpreter/ast"
 	"github.com/klaytonkowalski/example-interpreter/evaluator"
 	"github.com/klaytonkowalski/example-interpreter/lexer"
 	"github.com/klaytonkowalski/example-interpreter/object"
@@ -25,9 +26,9 @@ const prompt = ">> "
 // FUNCTIONS
 ////////////////////////////////////////////////////////////////////////////////
 
-func Start(in io.Reader, out io.Writer) {
+func Start(in io.Reader, out io.Writer, strict bool) {
 	scanner := bufio.NewScanner(in)
-	env := object.CreateEnvironment()
+	env := createEnvironment(strict)
 	for {
 		fmt.Fprintf(out, prompt)
 		scan := scanner.Scan()
@@ -37,8 +38,8 @@ func Start(in io.Reader, out io.Writer) {
 		line := scanner.Text()
 		lxr := lexer.New(line)
 		prs := parser.New(lxr)
-		program := prs.ParseProgram()
-		if len(prs.Errors) > 0 {
+		program, err := prs.ParseProgram()
+		if err != nil {
 			printParserErrors(out, prs.Errors)
 			continue
 		}
@@ -50,8 +51,37 @@ func Start(in io.Reader, out io.Writer) {
 	}
 }
 
-func printParserErrors(out io.Writer, errors []string) {
-	for _, message := range errors {
-		io.WriteString(out, "\t"+message+"\n")
+func Run(file string, script string, out io.Writer, strict bool) bool {
+	lxr := lexer.NewFile(file, script)
+	prs := parser.New(lxr)
+	program, err := prs.ParseProgram()
+	if err != nil {
+		printParserErrors(out, prs.Errors)
+		return false
+	}
+	return Execute(program, out, strict)
+}
+
+func Execute(program *ast.Program, out io.Writer, strict bool) bool {
+	env := createEnvironment(strict)
+	evaluated := evaluator.Evaluate(program, env)
+	if evaluated != nil && evaluated.GetType() == object.ObjectError {
+		io.WriteString(out, evaluated.GetDebugString())
+		io.WriteString(out, "\n")
+		return false
+	}
+	return true
+}
+
+func createEnvironment(strict bool) *object.Environment {
+	if strict {
+		return object.CreateStrictEnvironment()
+	}
+	return object.CreateEnvironment()
+}
+
+func printParserErrors(out io.Writer, errors []*parser.ParseError) {
+	for _, err := range errors {
+		io.WriteString(out, "\t"+err.Error()+"\n")
 	}
 }
@@ -1,51 +1,107 @@
 package token
 
+////////////////////////////////////////////////////////////////////////////////
+// DEPENDENCIES
+////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"fmt"
+)
+
 ////////////////////////////////////////////////////////////////////////////////
 // VARIABLES
 ////////////////////////////////////////////////////////////////////////////////
 
 const (
-	Illegal          = "Illegal"
-	End              = "End"
-	Identifier       = "Identifier"
-	Integer          = "Integer"
-	Equals           = "Equals"
-	Plus             = "Plus"
-	Comma            = "Comma"
-	Semicolon        = "Semicolon"
-	LeftParenthesis  = "LeftParenthesis"
-	RightParenthesis = "RightParenthesis"
-	LeftBrace        = "LeftBrace"
-	RightBrace       = "RightBrace"
-	Function         = "Function"
-	Let              = "Let"
-	Minus            = "Minus"
-	Bang             = "Bang"
-	Asterisk         = "Asterisk"
-	ForwardSlash     = "ForwardSlash"
-	LessThan         = "LessThan"
-	GreaterThan      = "GreaterThan"
-	True             = "True"
-	False            = "False"
-	If               = "If"
-	Else             = "Else"
-	Return           = "Return"
-	IsEqualTo        = "IsEqualTo"
-	IsNotEqualTo     = "IsNotEqualTo"
-	String           = "String"
-	LeftBracket      = "LeftBracket"
-	RightBracket     = "RightBracket"
-	Colon            = "Colon"
+	Illegal              = "Illegal"
+	End                  = "End"
+	Identifier           = "Identifier"
+	Integer              = "Integer"
+	Float                = "Float"
+	Equals               = "Equals"
+	Plus                 = "Plus"
+	PlusEquals           = "PlusEquals"
+	MinusEquals          = "MinusEquals"
+	AsteriskEquals       = "AsteriskEquals"
+	ForwardSlashEquals   = "ForwardSlashEquals"
+	Comma                = "Comma"
+	Semicolon            = "Semicolon"
+	LeftParenthesis      = "LeftParenthesis"
+	RightParenthesis     = "RightParenthesis"
+	LeftBrace            = "LeftBrace"
+	RightBrace           = "RightBrace"
+	Function             = "Function"
+	Let                  = "Let"
+	Const                = "Const"
+	Minus                = "Minus"
+	Bang                 = "Bang"
+	Asterisk             = "Asterisk"
+	ForwardSlash         = "ForwardSlash"
+	LessThan             = "LessThan"
+	GreaterThan          = "GreaterThan"
+	Percent              = "Percent"
+	DoubleAsterisk       = "DoubleAsterisk"
+	DoubleAmpersand      = "DoubleAmpersand"
+	DoublePipe           = "DoublePipe"
+	DoubleQuestion       = "DoubleQuestion"
+	QuestionDot          = "QuestionDot"
+	Ampersand            = "Ampersand"
+	Pipe                 = "Pipe"
+	Caret                = "Caret"
+	Tilde                = "Tilde"
+	DoubleLessThan       = "DoubleLessThan"
+	DoubleGreaterThan    = "DoubleGreaterThan"
+	True                 = "True"
+	False                = "False"
+	Null                 = "Null"
+	If                   = "If"
+	Match                = "Match"
+	Switch               = "Switch"
+	Case                 = "Case"
+	Default              = "Default"
+	FatArrow             = "FatArrow"
+	Else                 = "Else"
+	Return               = "Return"
+	While                = "While"
+	Break                = "Break"
+	Continue             = "Continue"
+	For                  = "For"
+	In                   = "In"
+	IsEqualTo            = "IsEqualTo"
+	IsNotEqualTo         = "IsNotEqualTo"
+	LessThanOrEqualTo    = "LessThanOrEqualTo"
+	GreaterThanOrEqualTo = "GreaterThanOrEqualTo"
+	String               = "String"
+	StringStart          = "StringStart"
+	StringMiddle         = "StringMiddle"
+	StringEnd            = "StringEnd"
+	LeftBracket          = "LeftBracket"
+	RightBracket         = "RightBracket"
+	Colon                = "Colon"
+	Ellipsis             = "Ellipsis"
+	Comment              = "Comment"
+	DocComment           = "DocComment"
 )
 
 var keywords = map[string]string{
-	"fn":     Function,
-	"let":    Let,
-	"true":   True,
-	"false":  False,
-	"if":     If,
-	"else":   Else,
-	"return": Return,
+	"fn":       Function,
+	"let":      Let,
+	"const":    Const,
+	"true":     True,
+	"false":    False,
+	"null":     Null,
+	"if":       If,
+	"match":    Match,
+	"switch":   Switch,
+	"case":     Case,
+	"default":  Default,
+	"else":     Else,
+	"return":   Return,
+	"while":    While,
+	"break":    Break,
+	"continue": Continue,
+	"for":      For,
+	"in":       In,
 }
 
 ////////////////////////////////////////////////////////////////////////////////
@@ -53,8 +109,37 @@ var keywords = map[string]string{
 ////////////////////////////////////////////////////////////////////////////////
 
 type Token struct {
-	Category string
-	Code     string
+	Category string   `json:"category"`
+	Code     string   `json:"code"`
+	Position Position `json:"position"`
+}
+
+type Position struct {
+	File   string `json:"file,omitempty"`
+	Line   int    `json:"line"`
+	Column int    `json:"column"`
+	Offset int    `json:"offset"`
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// METHODS
+////////////////////////////////////////////////////////////////////////////////
+
+func (p Position) IsValid() bool {
+	return p.Line > 0
+}
+
+func (p Position) String() string {
+	if !p.IsValid() {
+		if p.File != "" {
+			return p.File
+		}
+		return "-"
+	}
+	if p.File != "" {
+		return fmt.Sprintf("%s:%d:%d", p.File, p.Line, p.Column)
+	}
+	return fmt.Sprintf("%d:%d", p.Line, p.Column)
 }
 
 ////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,67 @@
+package main
+
+////////////////////////////////////////////////////////////////////////////////
+// DEPENDENCIES
+////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/klaytonkowalski/example-interpreter/lexer"
+	"github.com/klaytonkowalski/example-interpreter/token"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// FUNCTIONS
+////////////////////////////////////////////////////////////////////////////////
+
+func runTokens(args []string) {
+	flags := flag.NewFlagSet("tokens", flag.ExitOnError)
+	asJSON := flags.Bool("json", false, "print one JSON object per token")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "usage: tokens [-json] [file]")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+	file := "<stdin>"
+	var in io.Reader = os.Stdin
+	if flags.NArg() > 0 {
+		file = flags.Arg(0)
+		handle, err := os.Open(file)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		defer handle.Close()
+		in = handle
+	}
+	out := bufio.NewWriter(os.Stdout)
+	defer out.Flush()
+	lxr := lexer.NewReader(file, in)
+	encoder := json.NewEncoder(out)
+	encoder.SetEscapeHTML(false)
+	for lxr.Next() {
+		printToken(out, encoder, lxr.Token(), *asJSON)
+	}
+	printToken(out, encoder, lxr.Token(), *asJSON)
+	out.Flush()
+	for _, err := range lxr.Errors {
+		fmt.Fprintln(os.Stderr, err)
+	}
+	if len(lxr.Errors) > 0 {
+		os.Exit(1)
+	}
+}
+
+func printToken(out io.Writer, encoder *json.Encoder, tok token.Token, asJSON bool) {
+	if asJSON {
+		encoder.Encode(tok)
+		return
+	}
+	fmt.Fprintf(out, "%s\t%s\t%q\n", tok.Position, tok.Category, tok.Code)
+}